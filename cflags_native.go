@@ -0,0 +1,14 @@
+//go:build !portable
+
+package ffi
+
+// Default build: tune the C kernels for the build machine. See
+// cflags_portable.go for binaries that must run on other CPUs.
+
+/*
+#cgo CFLAGS: -O3 -march=native
+*/
+import "C"
+
+// PortableBuild reports whether the package was built with the portable tag.
+const PortableBuild = false
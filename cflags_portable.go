@@ -0,0 +1,14 @@
+//go:build portable
+
+package ffi
+
+// Portable build: no -march, so the binary runs on any CPU of the target
+// architecture, and SumSIMD uses the scalar kernel.
+
+/*
+#cgo CFLAGS: -O2 -DVECTOR_PORTABLE
+*/
+import "C"
+
+// PortableBuild reports whether the package was built with the portable tag.
+const PortableBuild = true
@@ -0,0 +1,177 @@
+package ffi
+
+/*
+#cgo LDFLAGS: -ldl
+#include <dlfcn.h>
+#include <stdlib.h>
+#include "vector.h"
+
+typedef double (*sum_fn)(const double*, size_t);
+typedef double (*dot_fn)(const double*, const double*, size_t);
+typedef void (*mul_fn)(const double*, const double*, double*, size_t);
+typedef void (*scale_fn)(double*, double, size_t);
+
+static double call_sum(void* fn, const double* arr, size_t len) {
+    return ((sum_fn)fn)(arr, len);
+}
+
+static double call_dot(void* fn, const double* a, const double* b, size_t len) {
+    return ((dot_fn)fn)(a, b, len);
+}
+
+static void call_mul(void* fn, const double* a, const double* b, double* result, size_t len) {
+    ((mul_fn)fn)(a, b, result, len);
+}
+
+static void call_scale(void* fn, double* arr, double scalar, size_t len) {
+    ((scale_fn)fn)(arr, scalar, len);
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+)
+
+// kernelLib holds kernels loaded from a shared object by NewVectorOpsFromLib.
+// A nil function pointer means the library does not export that kernel.
+type kernelLib struct {
+	path    string
+	handle  unsafe.Pointer
+	sum     unsafe.Pointer
+	sumSIMD unsafe.Pointer
+	dot     unsafe.Pointer
+	mul     unsafe.Pointer
+	scale   unsafe.Pointer
+}
+
+// NewVectorOpsFromLib is like NewVectorOps but runs Sum, SumSIMD, Dot, Mul,
+// MulInto and Scale through kernels loaded from the shared object at soPath,
+// so hand-tuned kernels can be swapped in without rebuilding the binary.
+// The library must export functions with the names and signatures declared
+// in vector.h. Kernels it does not export, and every other method, use the
+// built-in implementation. Close unloads the library.
+func NewVectorOpsFromLib(soPath string, capacity int) (*VectorOps, error) {
+	cPath := C.CString(soPath)
+	defer C.free(unsafe.Pointer(cPath))
+
+	handle := C.dlopen(cPath, C.RTLD_NOW|C.RTLD_LOCAL)
+	if handle == nil {
+		return nil, fmt.Errorf("failed to load %s: %s", soPath, C.GoString(C.dlerror()))
+	}
+
+	lib := &kernelLib{
+		path:    soPath,
+		handle:  handle,
+		sum:     lookupKernel(handle, "vector_sum"),
+		sumSIMD: lookupKernel(handle, "vector_sum_simd"),
+		dot:     lookupKernel(handle, "vector_dot"),
+		mul:     lookupKernel(handle, "vector_mul"),
+		scale:   lookupKernel(handle, "vector_scale"),
+	}
+	if lib.sum == nil && lib.sumSIMD == nil && lib.dot == nil && lib.mul == nil && lib.scale == nil {
+		C.dlclose(handle)
+		return nil, errors.New(soPath + " exports no vector kernels")
+	}
+
+	v := &VectorOps{pinThreshold: DefaultPinThreshold, lib: lib}
+	v.alloc(capacity)
+	return v, nil
+}
+
+// lookupKernel returns the address of symbol in the library, or nil.
+func lookupKernel(handle unsafe.Pointer, symbol string) unsafe.Pointer {
+	cName := C.CString(symbol)
+	defer C.free(unsafe.Pointer(cName))
+	return C.dlsym(handle, cName)
+}
+
+// close unloads the library.
+func (l *kernelLib) close() {
+	C.dlclose(l.handle)
+}
+
+// KernelInfo reports, for each kernel an op runs on ("sum", "sum_simd",
+// "dot", "mul", "scale"), which implementation it uses. The built-in C code
+// has no runtime dispatch: the SIMD extension is fixed when it is compiled,
+// so built-in kernels report the compile-time target, such as "avx2", or
+// "scalar" for loops the compiler cannot vectorize. Sum and Dot are strict
+// in-order reductions and always scalar, as is SumSIMD in portable builds.
+// Kernels loaded by NewVectorOpsFromLib report "lib:" and the library path.
+func (v *VectorOps) KernelInfo() map[string]string {
+	target := C.GoString(C.vector_simd_target())
+	simdSum := target
+	if PortableBuild {
+		simdSum = "scalar"
+	}
+
+	info := map[string]string{
+		"sum":      "scalar",
+		"sum_simd": simdSum,
+		"dot":      "scalar",
+		"mul":      target,
+		"scale":    target,
+	}
+	if v.lib != nil {
+		for name, fn := range map[string]unsafe.Pointer{
+			"sum":      v.lib.sum,
+			"sum_simd": v.lib.sumSIMD,
+			"dot":      v.lib.dot,
+			"mul":      v.lib.mul,
+			"scale":    v.lib.scale,
+		} {
+			if fn != nil {
+				info[name] = "lib:" + v.lib.path
+			}
+		}
+	}
+	return info
+}
+
+// The kernel methods below operate on the pinned buffers and dispatch to the
+// loaded library when it provides the kernel. Callers must hold the lock.
+
+func (v *VectorOps) sumKernel(n int) float64 {
+	return v.sumKernelAt(v.ptrA, n)
+}
+
+// sumKernelAt is sumKernel over n values at arr, which may be a pinned
+// caller slice instead of bufferA. It needs no lock when arr is not a buffer.
+func (v *VectorOps) sumKernelAt(arr *C.double, n int) float64 {
+	if v.lib != nil && v.lib.sum != nil {
+		return float64(C.call_sum(v.lib.sum, arr, C.size_t(n)))
+	}
+	return float64(C.vector_sum(arr, C.size_t(n)))
+}
+
+func (v *VectorOps) sumSIMDKernel(n int) float64 {
+	if v.lib != nil && v.lib.sumSIMD != nil {
+		return float64(C.call_sum(v.lib.sumSIMD, v.ptrA, C.size_t(n)))
+	}
+	return float64(C.vector_sum_simd(v.ptrA, C.size_t(n)))
+}
+
+func (v *VectorOps) dotKernel(n int) float64 {
+	if v.lib != nil && v.lib.dot != nil {
+		return float64(C.call_dot(v.lib.dot, v.ptrA, v.ptrB, C.size_t(n)))
+	}
+	return float64(C.vector_dot(v.ptrA, v.ptrB, C.size_t(n)))
+}
+
+func (v *VectorOps) mulKernel(n int) {
+	if v.lib != nil && v.lib.mul != nil {
+		C.call_mul(v.lib.mul, v.ptrA, v.ptrB, v.ptrR, C.size_t(n))
+		return
+	}
+	C.vector_mul(v.ptrA, v.ptrB, v.ptrR, C.size_t(n))
+}
+
+func (v *VectorOps) scaleKernel(scalar float64, n int) {
+	if v.lib != nil && v.lib.scale != nil {
+		C.call_scale(v.lib.scale, v.ptrA, C.double(scalar), C.size_t(n))
+		return
+	}
+	C.vector_scale(v.ptrA, C.double(scalar), C.size_t(n))
+}
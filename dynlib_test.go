@@ -0,0 +1,74 @@
+package ffi
+
+import (
+	"math"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// buildKernelLib compiles vector.c into a shared object in a temp directory.
+func buildKernelLib(t *testing.T) string {
+	cc, err := exec.LookPath("cc")
+	if err != nil {
+		t.Skip("no C compiler to build the kernel library")
+	}
+	so := filepath.Join(t.TempDir(), "libvector.so")
+	out, err := exec.Command(cc, "-O3", "-shared", "-fPIC", "-o", so, "vector.c", "-lm").CombinedOutput()
+	if err != nil {
+		t.Fatalf("building %s failed: %v\n%s", so, err, out)
+	}
+	return so
+}
+
+func TestNewVectorOpsFromLib(t *testing.T) {
+	ops, err := NewVectorOpsFromLib(buildKernelLib(t), 1000)
+	if err != nil {
+		t.Fatalf("NewVectorOpsFromLib failed: %v", err)
+	}
+	defer ops.Close()
+
+	a, b := makeData(1000), makeData(1000)
+	if got, want := ops.Sum(a), GoSum(a); math.Abs(got-want) > 1e-6 {
+		t.Errorf("Sum mismatch: Go=%v, lib=%v", want, got)
+	}
+	if got, want := ops.Dot(a, b), GoDot(a, b); math.Abs(got-want) > 1e-6 {
+		t.Errorf("Dot mismatch: Go=%v, lib=%v", want, got)
+	}
+	mul := ops.Mul(a, b)
+	for i := range mul {
+		if mul[i] != a[i]*b[i] {
+			t.Fatalf("Mul[%d] = %v, want %v", i, mul[i], a[i]*b[i])
+		}
+	}
+
+	if _, err := NewVectorOpsFromLib(filepath.Join(t.TempDir(), "missing.so"), 10); err == nil {
+		t.Error("NewVectorOpsFromLib succeeded on a missing library")
+	}
+}
+
+func TestKernelInfo(t *testing.T) {
+	ops := NewVectorOps(10)
+	defer ops.Close()
+
+	info := ops.KernelInfo()
+	t.Logf("built-in kernels: %v", info)
+	for _, op := range []string{"sum", "sum_simd", "dot", "mul", "scale"} {
+		if info[op] == "" {
+			t.Errorf("KernelInfo has no entry for %s", op)
+		}
+	}
+
+	so := buildKernelLib(t)
+	libOps, err := NewVectorOpsFromLib(so, 10)
+	if err != nil {
+		t.Fatalf("NewVectorOpsFromLib failed: %v", err)
+	}
+	defer libOps.Close()
+
+	libInfo := libOps.KernelInfo()
+	t.Logf("library kernels: %v", libInfo)
+	if got, want := libInfo["dot"], "lib:"+so; got != want {
+		t.Errorf("KernelInfo()[dot] = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,63 @@
+package ffi
+
+/*
+#include "vector.h"
+*/
+import "C"
+
+// EMA is an element-wise exponential moving average over a stream of
+// equal-length vectors. Each Update blends the new vector into the running
+// average held from the previous call. It uses the buffers of the VectorOps
+// that created it and holds that VectorOps' lock while reading or changing
+// its state, so it is exactly as safe for concurrent use as that VectorOps.
+type EMA struct {
+	ops   *VectorOps
+	alpha float64
+	prev  []float64 // running average; nil until the first Update
+}
+
+// NewEMA returns an EMA with smoothing factor alpha, which must be in (0, 1].
+// Larger alpha weighs new data more heavily. It returns nil for any other
+// alpha.
+func (v *VectorOps) NewEMA(alpha float64) *EMA {
+	if !(alpha > 0 && alpha <= 1) {
+		return nil
+	}
+	return &EMA{ops: v, alpha: alpha}
+}
+
+// Update folds data into the average, computing
+// out[i] = alpha*data[i] + (1-alpha)*prev[i], and returns a copy of the new
+// average. The first call seeds the average with data. Every later call must
+// pass the same length as the first; Update returns nil without changing the
+// state if it does not, or if data is empty or exceeds capacity.
+func (e *EMA) Update(data []float64) []float64 {
+	v := e.ops
+	v.lock()
+	defer v.unlock()
+
+	n := len(data)
+	if n == 0 || n > v.capacity || (e.prev != nil && n != len(e.prev)) {
+		return nil
+	}
+	if e.prev == nil {
+		e.prev = append([]float64(nil), data...)
+		return append([]float64(nil), e.prev...)
+	}
+
+	copy(v.bufferA[:n], data)
+	copy(v.bufferB[:n], e.prev)
+
+	C.vector_ema(v.ptrA, v.ptrB, v.ptrR, C.double(e.alpha), C.size_t(n))
+
+	copy(e.prev, v.result[:n])
+	return append([]float64(nil), e.prev...)
+}
+
+// Reset discards the running average, so the next Update starts a new
+// stream of any length.
+func (e *EMA) Reset() {
+	e.ops.lock()
+	defer e.ops.unlock()
+	e.prev = nil
+}
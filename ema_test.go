@@ -0,0 +1,49 @@
+package ffi
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEMAConverges(t *testing.T) {
+	ops := NewVectorOps(100)
+	defer ops.Close()
+
+	ema := ops.NewEMA(0.3)
+	if ema == nil {
+		t.Fatal("NewEMA(0.3) = nil")
+	}
+
+	// Seed at zero, then feed a constant; the gap shrinks by 1-alpha per step
+	ema.Update(make([]float64, 10))
+	target := makeData(10)
+	var out []float64
+	for step := 1; step <= 100; step++ {
+		out = ema.Update(target)
+		for i := range out {
+			want := target[i] * (1 - math.Pow(0.7, float64(step)))
+			if math.Abs(out[i]-want) > 1e-9 {
+				t.Fatalf("step %d: out[%d] = %v, want %v", step, i, out[i], want)
+			}
+		}
+	}
+	for i := range out {
+		if math.Abs(out[i]-target[i]) > 1e-6 {
+			t.Errorf("after 100 steps out[%d] = %v, want close to %v", i, out[i], target[i])
+		}
+	}
+
+	if got := ema.Update(make([]float64, 5)); got != nil {
+		t.Errorf("Update with a different length = %v, want nil", got)
+	}
+	ema.Reset()
+	if got := ema.Update(make([]float64, 5)); len(got) != 5 {
+		t.Errorf("Update after Reset returned %d elements, want 5", len(got))
+	}
+
+	for _, alpha := range []float64{0, -0.5, 1.5, math.NaN()} {
+		if ops.NewEMA(alpha) != nil {
+			t.Errorf("NewEMA(%v) != nil", alpha)
+		}
+	}
+}
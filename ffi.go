@@ -8,14 +8,16 @@
 package ffi
 
 /*
-#cgo CFLAGS: -O3 -march=native
+#cgo LDFLAGS: -lm
 #include "vector.h"
 */
 import "C"
 
 import (
+	"math"
 	"runtime"
 	"sync"
+	"time"
 	"unsafe"
 )
 
@@ -40,19 +42,98 @@ type VectorOps struct {
 	// Capacity
 	capacity int
 
+	// Inputs at least this long are pinned in place by the adaptive methods
+	pinThreshold int
+
+	// Buffers are padded and aligned for SIMD (NewVectorOpsAligned)
+	aligned bool
+
+	// Recycled result slices for MulPooled, stored as *[]float64
+	pool sync.Pool
+
 	// Mutex for thread safety (C code may not be thread-safe)
 	mu sync.Mutex
+
+	// Skip mu entirely (NewVectorOpsUnsafe)
+	unlocked bool
+
+	// Kernels loaded by NewVectorOpsFromLib, nil for the built-in ones
+	lib *kernelLib
 }
 
 // NewVectorOps creates a new VectorOps with pre-allocated buffers.
 // This is the one-time initialization cost.
 func NewVectorOps(capacity int) *VectorOps {
-	v := &VectorOps{
-		bufferA:  make([]float64, capacity),
-		bufferB:  make([]float64, capacity),
-		result:   make([]float64, capacity),
-		capacity: capacity,
+	v := &VectorOps{pinThreshold: DefaultPinThreshold}
+	v.alloc(capacity)
+	return v
+}
+
+// NewVectorOpsAligned is like NewVectorOps but pads each buffer to a whole
+// number of SIMD vectors and starts it on a simdAlign-byte boundary, so the
+// vectorized C loops need no unaligned prologue and little scalar remainder.
+// Capacity still reports the requested value.
+func NewVectorOpsAligned(capacity int) *VectorOps {
+	v := &VectorOps{pinThreshold: DefaultPinThreshold, aligned: true}
+	v.alloc(capacity)
+	return v
+}
+
+// NewVectorOpsUnsafe is like NewVectorOps but its methods do no locking,
+// which saves the mutex cost on every call in single-threaded hot loops.
+// The result is not safe for concurrent use; the caller must guarantee that
+// only one goroutine uses it at a time.
+func NewVectorOpsUnsafe(capacity int) *VectorOps {
+	v := &VectorOps{pinThreshold: DefaultPinThreshold, unlocked: true}
+	v.alloc(capacity)
+	return v
+}
+
+// lock acquires mu unless v was created by NewVectorOpsUnsafe.
+func (v *VectorOps) lock() {
+	if !v.unlocked {
+		v.mu.Lock()
+	}
+}
+
+// unlock releases mu unless v was created by NewVectorOpsUnsafe.
+func (v *VectorOps) unlock() {
+	if !v.unlocked {
+		v.mu.Unlock()
+	}
+}
+
+const (
+	simdWidth = 8  // float64 lanes in a 512-bit vector
+	simdAlign = 64 // bytes, the widest vector load
+)
+
+// alignedFloat64s returns a zeroed slice of n float64s whose first element
+// is simdAlign-byte aligned. The GC never moves heap objects, and the buffers
+// are pinned besides, so the alignment holds for the slice's lifetime.
+func alignedFloat64s(n int) []float64 {
+	raw := make([]float64, n+simdAlign/8)
+	off := 0
+	for uintptr(unsafe.Pointer(&raw[off]))%simdAlign != 0 {
+		off++
+	}
+	return raw[off : off+n : off+n]
+}
+
+// alloc allocates and pins fresh zeroed buffers of the given capacity.
+// Any previous buffers must already be unpinned.
+func (v *VectorOps) alloc(capacity int) {
+	if v.aligned {
+		size := (capacity + simdWidth - 1) / simdWidth * simdWidth
+		v.bufferA = alignedFloat64s(size)
+		v.bufferB = alignedFloat64s(size)
+		v.result = alignedFloat64s(size)
+	} else {
+		v.bufferA = make([]float64, capacity)
+		v.bufferB = make([]float64, capacity)
+		v.result = make([]float64, capacity)
 	}
+	v.capacity = capacity
 
 	// Pin the buffers so GC won't move them
 	v.pinnerA.Pin(&v.bufferA[0])
@@ -63,8 +144,20 @@ func NewVectorOps(capacity int) *VectorOps {
 	v.ptrA = (*C.double)(unsafe.Pointer(&v.bufferA[0]))
 	v.ptrB = (*C.double)(unsafe.Pointer(&v.bufferB[0]))
 	v.ptrR = (*C.double)(unsafe.Pointer(&v.result[0]))
+}
 
-	return v
+// Reinit unpins the current buffers and replaces them with zeroed buffers of
+// the new capacity, returning v to the state NewVectorOps(capacity) would
+// produce. It is meant for pooled instances handed to tasks of a different
+// size. Settings such as the pin threshold and alignment are kept.
+func (v *VectorOps) Reinit(capacity int) {
+	v.lock()
+	defer v.unlock()
+
+	v.pinnerA.Unpin()
+	v.pinnerB.Unpin()
+	v.pinnerR.Unpin()
+	v.alloc(capacity)
 }
 
 // Close releases pinned memory. Must be called when done.
@@ -72,6 +165,10 @@ func (v *VectorOps) Close() {
 	v.pinnerA.Unpin()
 	v.pinnerB.Unpin()
 	v.pinnerR.Unpin()
+	if v.lib != nil {
+		v.lib.close()
+		v.lib = nil
+	}
 }
 
 // Sum returns the sum of all elements.
@@ -85,14 +182,20 @@ func (v *VectorOps) Sum(data []float64) float64 {
 		n = v.capacity
 	}
 
-	v.mu.Lock()
-	defer v.mu.Unlock()
+	v.lock()
+	defer v.unlock()
 
 	// Copy data to pinned buffer
 	copy(v.bufferA[:n], data[:n])
 
 	// Call C - this is now just a function call, no allocation
-	return float64(C.vector_sum(v.ptrA, C.size_t(n)))
+	return v.sumKernel(n)
+}
+
+// SumN is Sum that also reports how many elements were summed. processed is
+// less than len(data) when data exceeded the capacity and was truncated.
+func (v *VectorOps) SumN(data []float64) (result float64, processed int) {
+	return v.Sum(data), min(len(data), v.capacity)
 }
 
 // SumSIMD uses the SIMD-optimized C function.
@@ -105,11 +208,11 @@ func (v *VectorOps) SumSIMD(data []float64) float64 {
 		n = v.capacity
 	}
 
-	v.mu.Lock()
-	defer v.mu.Unlock()
+	v.lock()
+	defer v.unlock()
 
 	copy(v.bufferA[:n], data[:n])
-	return float64(C.vector_sum_simd(v.ptrA, C.size_t(n)))
+	return v.sumSIMDKernel(n)
 }
 
 // Dot computes the dot product of two vectors.
@@ -122,13 +225,23 @@ func (v *VectorOps) Dot(a, b []float64) float64 {
 		n = v.capacity
 	}
 
-	v.mu.Lock()
-	defer v.mu.Unlock()
+	v.lock()
+	defer v.unlock()
 
 	copy(v.bufferA[:n], a[:n])
 	copy(v.bufferB[:n], b[:n])
 
-	return float64(C.vector_dot(v.ptrA, v.ptrB, C.size_t(n)))
+	return v.dotKernel(n)
+}
+
+// DotN is Dot that also reports how many element pairs were used. processed
+// is less than len(a) when a exceeded the capacity, and 0 when b is shorter
+// than a.
+func (v *VectorOps) DotN(a, b []float64) (result float64, processed int) {
+	if len(b) < len(a) {
+		return 0, 0
+	}
+	return v.Dot(a, b), min(len(a), v.capacity)
 }
 
 // Mul performs element-wise multiplication: result[i] = a[i] * b[i]
@@ -142,13 +255,13 @@ func (v *VectorOps) Mul(a, b []float64) []float64 {
 		n = v.capacity
 	}
 
-	v.mu.Lock()
-	defer v.mu.Unlock()
+	v.lock()
+	defer v.unlock()
 
 	copy(v.bufferA[:n], a[:n])
 	copy(v.bufferB[:n], b[:n])
 
-	C.vector_mul(v.ptrA, v.ptrB, v.ptrR, C.size_t(n))
+	v.mulKernel(n)
 
 	// Return a copy to avoid data races after unlock
 	result := make([]float64, n)
@@ -167,13 +280,13 @@ func (v *VectorOps) MulInto(a, b, dst []float64) {
 		n = v.capacity
 	}
 
-	v.mu.Lock()
-	defer v.mu.Unlock()
+	v.lock()
+	defer v.unlock()
 
 	copy(v.bufferA[:n], a[:n])
 	copy(v.bufferB[:n], b[:n])
 
-	C.vector_mul(v.ptrA, v.ptrB, v.ptrR, C.size_t(n))
+	v.mulKernel(n)
 
 	copy(dst[:n], v.result[:n])
 }
@@ -188,16 +301,640 @@ func (v *VectorOps) Scale(data []float64, scalar float64) {
 		n = v.capacity
 	}
 
-	v.mu.Lock()
-	defer v.mu.Unlock()
+	v.lock()
+	defer v.unlock()
 
 	copy(v.bufferA[:n], data[:n])
 
-	C.vector_scale(v.ptrA, C.double(scalar), C.size_t(n))
+	v.scaleKernel(scalar, n)
 
 	copy(data[:n], v.bufferA[:n])
 }
 
+// Validate counts the NaN and infinite (+Inf or -Inf) values in data.
+// Use it to reject bad upstream data before running other operations.
+func (v *VectorOps) Validate(data []float64) (nanCount, infCount int) {
+	n := len(data)
+	if n == 0 {
+		return 0, 0
+	}
+	if n > v.capacity {
+		n = v.capacity
+	}
+
+	v.lock()
+	defer v.unlock()
+
+	copy(v.bufferA[:n], data[:n])
+
+	var nans, infs C.size_t
+	C.vector_validate(v.ptrA, C.size_t(n), &nans, &infs)
+	return int(nans), int(infs)
+}
+
+// Softmax returns the softmax of data. The max element is subtracted before
+// exponentiation, so large inputs do not overflow to Inf.
+func (v *VectorOps) Softmax(data []float64) []float64 {
+	n := len(data)
+	if n == 0 {
+		return nil
+	}
+	if n > v.capacity {
+		n = v.capacity
+	}
+
+	v.lock()
+	defer v.unlock()
+
+	copy(v.bufferA[:n], data[:n])
+
+	C.vector_softmax(v.ptrA, v.ptrR, C.size_t(n))
+
+	result := make([]float64, n)
+	copy(result, v.result[:n])
+	return result
+}
+
+// QuantizeInt8 returns round(data[i]/scale) clamped to [-128, 127].
+// Rounding is half away from zero; NaN quantizes to 0.
+// The int8 values are written into the result buffer's memory, so no extra
+// pinned buffer is needed.
+func (v *VectorOps) QuantizeInt8(data []float64, scale float64) []int8 {
+	n := len(data)
+	if n == 0 {
+		return nil
+	}
+	if n > v.capacity {
+		n = v.capacity
+	}
+
+	v.lock()
+	defer v.unlock()
+
+	copy(v.bufferA[:n], data[:n])
+
+	C.vector_quantize_i8(v.ptrA, C.double(scale), (*C.int8_t)(unsafe.Pointer(v.ptrR)), C.size_t(n))
+
+	result := make([]int8, n)
+	copy(result, unsafe.Slice((*int8)(unsafe.Pointer(v.ptrR)), n))
+	return result
+}
+
+// simdTolerance is the relative difference SumSIMDVerify accepts between the
+// SIMD and scalar sums, which legitimately differ by rounding order.
+const simdTolerance = 1e-9
+
+// SumSIMDVerify runs both the SIMD and scalar C sums on the same data and
+// reports whether they agree within simdTolerance. It is an opt-in diagnostic
+// for checking the -march=native path on a given CPU.
+func (v *VectorOps) SumSIMDVerify(data []float64) (result float64, scalarResult float64, agree bool) {
+	n := len(data)
+	if n == 0 {
+		return 0, 0, true
+	}
+	if n > v.capacity {
+		n = v.capacity
+	}
+
+	v.lock()
+	defer v.unlock()
+
+	copy(v.bufferA[:n], data[:n])
+
+	result = float64(C.vector_sum_simd(v.ptrA, C.size_t(n)))
+	scalarResult = float64(C.vector_sum(v.ptrA, C.size_t(n)))
+
+	agree = math.Abs(result-scalarResult) <= simdTolerance*math.Max(1, math.Abs(scalarResult))
+	return result, scalarResult, agree
+}
+
+// SumRange returns the sum of data[start:start+length], copying only that
+// window into the pinned buffer. A start or length outside data is clamped
+// to the valid range, so an empty window sums to zero.
+func (v *VectorOps) SumRange(data []float64, start, length int) float64 {
+	start = max(0, min(start, len(data)))
+	length = max(0, min(length, len(data)-start))
+	return v.Sum(data[start : start+length])
+}
+
+// Quantile returns the q-th quantile of data, interpolating linearly between
+// the two nearest ranks, so q=0.5 is the median. The data is sorted in the
+// pinned buffer; the caller's slice is left untouched. It returns NaN if data
+// is empty or q is outside [0, 1].
+func (v *VectorOps) Quantile(data []float64, q float64) float64 {
+	n := len(data)
+	if n == 0 || !(q >= 0 && q <= 1) {
+		return math.NaN()
+	}
+	if n > v.capacity {
+		n = v.capacity
+	}
+
+	v.lock()
+	defer v.unlock()
+
+	copy(v.bufferA[:n], data[:n])
+
+	return float64(C.vector_quantile(v.ptrA, C.size_t(n), C.double(q)))
+}
+
+// GeometricMean returns the n-th root of the product of data, computed as
+// the exponential of the mean logarithm so that many large or small values
+// neither overflow nor underflow. It returns NaN if data is empty or any
+// element is zero, negative or NaN.
+func (v *VectorOps) GeometricMean(data []float64) float64 {
+	n := len(data)
+	if n == 0 {
+		return math.NaN()
+	}
+	if n > v.capacity {
+		n = v.capacity
+	}
+
+	v.lock()
+	defer v.unlock()
+
+	copy(v.bufferA[:n], data[:n])
+
+	return float64(C.vector_geomean(v.ptrA, C.size_t(n)))
+}
+
+// DefaultPinThreshold is the input length at which the adaptive methods stop
+// copying into the pre-allocated buffer and pin the caller's slice instead.
+// Below it the copy is cheaper than pinning; above it, skipping the copy wins.
+const DefaultPinThreshold = 1024
+
+// SetPinThreshold sets the input length at which SumAdaptive switches from
+// copying to pinning in place.
+func (v *VectorOps) SetPinThreshold(n int) {
+	v.lock()
+	defer v.unlock()
+	v.pinThreshold = n
+}
+
+// SumAdaptive returns the sum of data, copying it into the pre-allocated
+// buffer when it is shorter than the pin threshold and pinning it in place
+// otherwise. Data longer than capacity is always pinned, so it is summed in
+// full. Both paths run the same kernel, including one loaded by
+// NewVectorOpsFromLib.
+func (v *VectorOps) SumAdaptive(data []float64) float64 {
+	v.lock()
+	threshold, capacity := v.pinThreshold, v.capacity
+	v.unlock()
+
+	n := len(data)
+	if n < threshold && n <= capacity {
+		return v.Sum(data)
+	}
+	if n == 0 {
+		return 0
+	}
+
+	var pinner runtime.Pinner
+	pinner.Pin(&data[0])
+	defer pinner.Unpin()
+
+	return v.sumKernelAt((*C.double)(unsafe.Pointer(&data[0])), n)
+}
+
+// Exp returns e**data[i] for each element.
+func (v *VectorOps) Exp(data []float64) []float64 {
+	n := len(data)
+	if n == 0 {
+		return nil
+	}
+	if n > v.capacity {
+		n = v.capacity
+	}
+
+	v.lock()
+	defer v.unlock()
+
+	copy(v.bufferA[:n], data[:n])
+
+	C.vector_exp(v.ptrA, v.ptrR, C.size_t(n))
+
+	result := make([]float64, n)
+	copy(result, v.result[:n])
+	return result
+}
+
+// Log returns the natural logarithm of each element. As with math.Log,
+// zero gives -Inf and negative values give NaN.
+func (v *VectorOps) Log(data []float64) []float64 {
+	n := len(data)
+	if n == 0 {
+		return nil
+	}
+	if n > v.capacity {
+		n = v.capacity
+	}
+
+	v.lock()
+	defer v.unlock()
+
+	copy(v.bufferA[:n], data[:n])
+
+	C.vector_log(v.ptrA, v.ptrR, C.size_t(n))
+
+	result := make([]float64, n)
+	copy(result, v.result[:n])
+	return result
+}
+
+// Pow returns data[i]**exponent for each element. Integral exponents up to 64
+// in magnitude use repeated squaring, which can differ from math.Pow in the
+// last bit. Negative bases with fractional exponents give NaN, as in IEEE 754.
+func (v *VectorOps) Pow(data []float64, exponent float64) []float64 {
+	n := len(data)
+	if n == 0 {
+		return nil
+	}
+	if n > v.capacity {
+		n = v.capacity
+	}
+
+	v.lock()
+	defer v.unlock()
+
+	copy(v.bufferA[:n], data[:n])
+
+	C.vector_pow(v.ptrA, v.ptrR, C.size_t(n), C.double(exponent))
+
+	result := make([]float64, n)
+	copy(result, v.result[:n])
+	return result
+}
+
+// SumTimed is Sum instrumented to report how long the copy into the pinned
+// buffer and the C call each took. It is a diagnostic for deciding whether
+// copying or computing dominates at a given size; use Sum otherwise.
+func (v *VectorOps) SumTimed(data []float64) (result float64, copyDur, computeDur time.Duration) {
+	n := len(data)
+	if n == 0 {
+		return 0, 0, 0
+	}
+	if n > v.capacity {
+		n = v.capacity
+	}
+
+	v.lock()
+	defer v.unlock()
+
+	start := time.Now()
+	copy(v.bufferA[:n], data[:n])
+	copied := time.Now()
+	result = v.sumKernel(n)
+	copyDur, computeDur = copied.Sub(start), time.Since(copied)
+
+	return result, copyDur, computeDur
+}
+
+// CPUSupportsSIMD reports whether the CPU running this process supports the
+// SIMD extensions the C code was compiled for with -march=native. When it
+// returns false the binary was built on a newer CPU, and SumSIMD (or any
+// auto-vectorized C path) may crash with SIGILL.
+func CPUSupportsSIMD() bool {
+	return C.vector_cpu_supports_simd() != 0
+}
+
+// Interleave returns [a0, b0, a1, b1, ...]. Pairs are taken up to the shorter
+// of a and b, and limited so the 2n-element result fits in capacity.
+func (v *VectorOps) Interleave(a, b []float64) []float64 {
+	n := min(len(a), len(b))
+	if n == 0 {
+		return nil
+	}
+	if 2*n > v.capacity {
+		n = v.capacity / 2
+	}
+
+	v.lock()
+	defer v.unlock()
+
+	copy(v.bufferA[:n], a[:n])
+	copy(v.bufferB[:n], b[:n])
+
+	C.vector_interleave(v.ptrA, v.ptrB, v.ptrR, C.size_t(n))
+
+	result := make([]float64, 2*n)
+	copy(result, v.result[:2*n])
+	return result
+}
+
+// Deinterleave splits data into its even- and odd-indexed elements, the
+// inverse of Interleave. For odd-length data evens has one extra element.
+func (v *VectorOps) Deinterleave(data []float64) (evens, odds []float64) {
+	n := len(data)
+	if n == 0 {
+		return nil, nil
+	}
+	if n > v.capacity {
+		n = v.capacity
+	}
+
+	v.lock()
+	defer v.unlock()
+
+	copy(v.bufferA[:n], data[:n])
+
+	C.vector_deinterleave(v.ptrA, v.ptrB, v.ptrR, C.size_t(n))
+
+	evens = make([]float64, (n+1)/2)
+	odds = make([]float64, n/2)
+	copy(evens, v.bufferB)
+	copy(odds, v.result)
+	return evens, odds
+}
+
+// RunningMax returns the running maximum of data: out[i] = max(data[0..i]).
+func (v *VectorOps) RunningMax(data []float64) []float64 {
+	n := len(data)
+	if n == 0 {
+		return []float64{}
+	}
+	if n > v.capacity {
+		n = v.capacity
+	}
+
+	v.lock()
+	defer v.unlock()
+
+	copy(v.bufferA[:n], data[:n])
+
+	C.vector_runmax(v.ptrA, v.ptrR, C.size_t(n))
+
+	result := make([]float64, n)
+	copy(result, v.result[:n])
+	return result
+}
+
+// MulPooled is like Mul but takes the result slice from an internal pool.
+// Call release once the result is no longer needed to return it to the pool;
+// the slice must not be used after release. This cuts allocation in loops
+// where the result has to outlive the call, so MulInto does not fit.
+func (v *VectorOps) MulPooled(a, b []float64) (result []float64, release func()) {
+	n := len(a)
+	if n == 0 || len(b) < n {
+		return nil, func() {}
+	}
+	if n > v.capacity {
+		n = v.capacity
+	}
+
+	v.lock()
+	defer v.unlock()
+
+	copy(v.bufferA[:n], a[:n])
+	copy(v.bufferB[:n], b[:n])
+
+	v.mulKernel(n)
+
+	buf, ok := v.pool.Get().(*[]float64)
+	if !ok || cap(*buf) < n {
+		s := make([]float64, n)
+		buf = &s
+	}
+	*buf = (*buf)[:n]
+	copy(*buf, v.result[:n])
+	return *buf, func() { v.pool.Put(buf) }
+}
+
+// GreaterThan returns a mask with true where data[i] > threshold.
+// NaN compares false.
+func (v *VectorOps) GreaterThan(data []float64, threshold float64) []bool {
+	n := len(data)
+	if n == 0 {
+		return nil
+	}
+	if n > v.capacity {
+		n = v.capacity
+	}
+
+	v.lock()
+	defer v.unlock()
+
+	copy(v.bufferA[:n], data[:n])
+
+	C.vector_gt(v.ptrA, C.double(threshold), v.ptrR, C.size_t(n))
+
+	mask := make([]bool, n)
+	for i, x := range v.result[:n] {
+		mask[i] = x != 0
+	}
+	return mask
+}
+
+// CountGreater returns the number of elements strictly greater than threshold.
+func (v *VectorOps) CountGreater(data []float64, threshold float64) int {
+	n := len(data)
+	if n == 0 {
+		return 0
+	}
+	if n > v.capacity {
+		n = v.capacity
+	}
+
+	v.lock()
+	defer v.unlock()
+
+	copy(v.bufferA[:n], data[:n])
+
+	return int(C.vector_count_gt(v.ptrA, C.double(threshold), C.size_t(n)))
+}
+
+// AddSat adds a and b element-wise and clamps each sum to [lo, hi], e.g. to
+// mix audio channels without wrapping past full scale. NaN sums are passed
+// through unclamped.
+func (v *VectorOps) AddSat(a, b []float64, lo, hi float64) []float64 {
+	n := len(a)
+	if n == 0 || len(b) < n {
+		return nil
+	}
+	if n > v.capacity {
+		n = v.capacity
+	}
+
+	v.lock()
+	defer v.unlock()
+
+	copy(v.bufferA[:n], a[:n])
+	copy(v.bufferB[:n], b[:n])
+
+	C.vector_add_sat(v.ptrA, v.ptrB, v.ptrR, C.size_t(n), C.double(lo), C.double(hi))
+
+	result := make([]float64, n)
+	copy(result, v.result[:n])
+	return result
+}
+
+// ScaleRows multiplies each row of the row-major rows x cols matrix in-place
+// by the matching element of scalars, as in per-row normalization. It
+// reports false and leaves matrix untouched if len(matrix) != rows*cols,
+// len(scalars) != rows, or the matrix does not fit in capacity.
+func (v *VectorOps) ScaleRows(matrix []float64, rows, cols int, scalars []float64) bool {
+	if rows < 0 || cols < 0 || len(matrix) != rows*cols || len(scalars) != rows {
+		return false
+	}
+	n := len(matrix)
+	if n > v.capacity {
+		return false
+	}
+	if n == 0 {
+		return true
+	}
+
+	v.lock()
+	defer v.unlock()
+
+	copy(v.bufferA[:n], matrix)
+	copy(v.bufferB[:rows], scalars)
+
+	C.vector_scale_rows(v.ptrA, v.ptrB, C.size_t(rows), C.size_t(cols))
+
+	copy(matrix, v.bufferA[:n])
+	return true
+}
+
+// NormalizeRows scales each row of the row-major rows x cols matrix in-place
+// to unit L2 norm, the usual preparation for cosine similarity. Rows that
+// are all zero are left unchanged. It reports false and leaves matrix
+// untouched if len(matrix) != rows*cols or the matrix does not fit in
+// capacity.
+func (v *VectorOps) NormalizeRows(matrix []float64, rows, cols int) bool {
+	if rows < 0 || cols < 0 || len(matrix) != rows*cols {
+		return false
+	}
+	n := len(matrix)
+	if n > v.capacity {
+		return false
+	}
+	if n == 0 {
+		return true
+	}
+
+	v.lock()
+	defer v.unlock()
+
+	copy(v.bufferA[:n], matrix)
+
+	C.vector_normalize_rows(v.ptrA, C.size_t(rows), C.size_t(cols))
+
+	copy(matrix, v.bufferA[:n])
+	return true
+}
+
+// Outer returns the outer product of a and b as a row-major
+// len(a) x len(b) matrix, out[i*len(b)+j] = a[i]*b[j]. It returns nil if
+// either input is empty or the matrix would exceed capacity.
+func (v *VectorOps) Outer(a, b []float64) []float64 {
+	n := len(a) * len(b)
+	if n == 0 || n > v.capacity {
+		return nil
+	}
+
+	v.lock()
+	defer v.unlock()
+
+	copy(v.bufferA, a)
+	copy(v.bufferB, b)
+
+	C.vector_outer(v.ptrA, C.size_t(len(a)), v.ptrB, C.size_t(len(b)), v.ptrR)
+
+	result := make([]float64, n)
+	copy(result, v.result[:n])
+	return result
+}
+
+// DotExtended is Dot with compensated accumulation, giving roughly twice
+// double precision. Use it for nearly cancelling vectors, where Dot can lose
+// every significant digit. It is slower than Dot since it cannot vectorize.
+func (v *VectorOps) DotExtended(a, b []float64) float64 {
+	n := len(a)
+	if n == 0 || len(b) < n {
+		return 0
+	}
+	if n > v.capacity {
+		n = v.capacity
+	}
+
+	v.lock()
+	defer v.unlock()
+
+	copy(v.bufferA[:n], a[:n])
+	copy(v.bufferB[:n], b[:n])
+
+	return float64(C.vector_dot_extended(v.ptrA, v.ptrB, C.size_t(n)))
+}
+
+// DotReproducible computes the dot product with a fixed pairwise reduction
+// tree, so the result is bit-identical on every platform and SIMD width and
+// matches GoDotReproducible exactly. Dot may differ in the last bits between
+// machines. It overwrites the result buffer.
+func (v *VectorOps) DotReproducible(a, b []float64) float64 {
+	n := len(a)
+	if n == 0 || len(b) < n {
+		return 0
+	}
+	if n > v.capacity {
+		n = v.capacity
+	}
+
+	v.lock()
+	defer v.unlock()
+
+	copy(v.bufferA[:n], a[:n])
+	copy(v.bufferB[:n], b[:n])
+
+	return float64(C.vector_dot_reproducible(v.ptrA, v.ptrB, v.ptrR, C.size_t(n)))
+}
+
+// MeanStdDev returns the mean and population standard deviation of data in
+// a single pass using Welford's algorithm, which stays accurate on data with
+// a large offset where sum-of-squares formulas cancel catastrophically. It
+// returns NaN for both if data is empty.
+func (v *VectorOps) MeanStdDev(data []float64) (mean, std float64) {
+	n := len(data)
+	if n == 0 {
+		return math.NaN(), math.NaN()
+	}
+	if n > v.capacity {
+		n = v.capacity
+	}
+
+	v.lock()
+	defer v.unlock()
+
+	copy(v.bufferA[:n], data[:n])
+
+	var m, s C.double
+	C.vector_mean_std(v.ptrA, C.size_t(n), &m, &s)
+	return float64(m), float64(s)
+}
+
+// StdDev returns the population standard deviation of data; see MeanStdDev.
+func (v *VectorOps) StdDev(data []float64) float64 {
+	_, std := v.MeanStdDev(data)
+	return std
+}
+
+// Prefetch copies data into the input buffer and asks the CPU to pull the
+// input and result buffers into cache, so the first timed iterations of a
+// benchmark don't pay for cold misses. Call it before b.ResetTimer. It has no
+// effect on results.
+func (v *VectorOps) Prefetch(data []float64) {
+	n := len(data)
+	if n > v.capacity {
+		n = v.capacity
+	}
+
+	v.lock()
+	defer v.unlock()
+
+	copy(v.bufferA[:n], data[:n])
+	C.vector_prefetch(v.ptrA, v.ptrR, C.size_t(n))
+}
+
 // --- Direct FFI calls (for comparison - shows per-call overhead) ---
 
 // DirectSum calls C directly without pre-allocated buffers.
@@ -2,8 +2,11 @@ package ffi
 
 import (
 	"math"
+	"math/big"
 	"math/rand"
+	"slices"
 	"testing"
+	"unsafe"
 )
 
 // Test data sizes
@@ -67,6 +70,792 @@ func TestMulCorrectness(t *testing.T) {
 	}
 }
 
+func TestValidateCorrectness(t *testing.T) {
+	data := []float64{1, math.NaN(), 2.5, math.Inf(1), -3, math.Inf(-1), math.NaN(), 0, math.NaN()}
+
+	goNaN, goInf := GoValidate(data)
+
+	ops := NewVectorOps(len(data))
+	defer ops.Close()
+	cNaN, cInf := ops.Validate(data)
+
+	if goNaN != 3 || goInf != 2 {
+		t.Errorf("GoValidate = (%d, %d), want (3, 2)", goNaN, goInf)
+	}
+	if cNaN != goNaN || cInf != goInf {
+		t.Errorf("Validate mismatch: Go=(%d, %d), C=(%d, %d)", goNaN, goInf, cNaN, cInf)
+	}
+}
+
+func TestSoftmaxCorrectness(t *testing.T) {
+	data := makeData(100)
+
+	goResult := GoSoftmax(data)
+
+	ops := NewVectorOps(len(data))
+	defer ops.Close()
+	cResult := ops.Softmax(data)
+
+	var sum float64
+	for i := range goResult {
+		if math.Abs(goResult[i]-cResult[i]) > 1e-12 {
+			t.Errorf("Softmax mismatch at %d: Go=%v, C=%v", i, goResult[i], cResult[i])
+			break
+		}
+		sum += cResult[i]
+	}
+	if math.Abs(sum-1) > 1e-9 {
+		t.Errorf("Softmax sums to %v, want 1", sum)
+	}
+
+	// exp(1000) overflows; the max-subtraction must keep this finite
+	large := ops.Softmax([]float64{1000, 1000, 999})
+	for i, p := range large {
+		if math.IsInf(p, 0) || math.IsNaN(p) {
+			t.Errorf("Softmax(large)[%d] = %v, want finite", i, p)
+		}
+	}
+	if math.Abs(large[0]-large[1]) > 1e-12 || large[0] <= large[2] {
+		t.Errorf("Softmax(large) = %v, want equal first two and smaller third", large)
+	}
+}
+
+func TestQuantizeInt8Correctness(t *testing.T) {
+	data := []float64{0, 0.24, 0.25, -0.25, 1.0, 31.74, -32, 1000, -1000, math.NaN()}
+	want := []int8{0, 1, 1, -1, 4, 127, -128, 127, -128, 0}
+
+	goResult := GoQuantizeInt8(data, 0.25)
+
+	ops := NewVectorOps(len(data))
+	defer ops.Close()
+	cResult := ops.QuantizeInt8(data, 0.25)
+
+	for i := range want {
+		if goResult[i] != want[i] {
+			t.Errorf("GoQuantizeInt8(%v) = %d, want %d", data[i], goResult[i], want[i])
+		}
+		if cResult[i] != want[i] {
+			t.Errorf("QuantizeInt8(%v) = %d, want %d", data[i], cResult[i], want[i])
+		}
+	}
+}
+
+func TestSumSIMDVerify(t *testing.T) {
+	data := makeData(10000)
+
+	ops := NewVectorOps(len(data))
+	defer ops.Close()
+	simd, scalar, agree := ops.SumSIMDVerify(data)
+
+	if !agree {
+		t.Errorf("SIMD and scalar sums disagree: SIMD=%v, scalar=%v", simd, scalar)
+	}
+	if math.Abs(scalar-GoSum(data)) > 1e-6 {
+		t.Errorf("scalar sum mismatch: Go=%v, C=%v", GoSum(data), scalar)
+	}
+}
+
+func TestSumRange(t *testing.T) {
+	data := makeData(1000)
+
+	ops := NewVectorOps(len(data))
+	defer ops.Close()
+
+	goResult := GoSum(data[250:750])
+	cResult := ops.SumRange(data, 250, 500)
+	if math.Abs(goResult-cResult) > 1e-6 {
+		t.Errorf("SumRange mismatch: Go=%v, C=%v", goResult, cResult)
+	}
+
+	// Out-of-range windows clamp instead of panicking
+	if got, want := ops.SumRange(data, 900, 500), GoSum(data[900:]); math.Abs(got-want) > 1e-6 {
+		t.Errorf("SumRange(past end) = %v, want %v", got, want)
+	}
+	if got, want := ops.SumRange(data, -10, 20), GoSum(data[:20]); math.Abs(got-want) > 1e-6 {
+		t.Errorf("SumRange(negative start) = %v, want %v", got, want)
+	}
+	if got := ops.SumRange(data, 2000, 10); got != 0 {
+		t.Errorf("SumRange(start past end) = %v, want 0", got)
+	}
+}
+
+func TestQuantileCorrectness(t *testing.T) {
+	ops := NewVectorOps(100)
+	defer ops.Close()
+
+	data := []float64{7, 1, 5, 3, 9}
+	if got := ops.Quantile(data, 0.5); got != 5 {
+		t.Errorf("Quantile(0.5) = %v, want 5", got)
+	}
+	if data[0] != 7 {
+		t.Errorf("Quantile modified input: %v", data)
+	}
+
+	// Even length interpolates between the middle two values
+	if got := ops.Quantile([]float64{4, 1, 3, 2}, 0.5); got != 2.5 {
+		t.Errorf("Quantile(even, 0.5) = %v, want 2.5", got)
+	}
+
+	random := makeData(100)
+	for _, q := range []float64{0, 0.25, 0.9, 1} {
+		if got, want := ops.Quantile(random, q), GoQuantile(random, q); math.Abs(got-want) > 1e-9 {
+			t.Errorf("Quantile(%v) mismatch: Go=%v, C=%v", q, want, got)
+		}
+	}
+
+	for _, q := range []float64{-0.1, 1.1, math.NaN()} {
+		if got := ops.Quantile(data, q); !math.IsNaN(got) {
+			t.Errorf("Quantile(%v) = %v, want NaN", q, got)
+		}
+	}
+}
+
+func TestSumAdaptive(t *testing.T) {
+	ops := NewVectorOps(1000)
+	defer ops.Close()
+	ops.SetPinThreshold(500)
+
+	// Below the threshold: copied into the buffer
+	small := makeData(100)
+	if got, want := ops.SumAdaptive(small), GoSum(small); math.Abs(got-want) > 1e-6 {
+		t.Errorf("SumAdaptive(small) mismatch: Go=%v, C=%v", want, got)
+	}
+
+	// At and above the threshold: pinned in place, even past capacity
+	for _, n := range []int{500, 5000} {
+		large := makeData(n)
+		if got, want := ops.SumAdaptive(large), GoSum(large); math.Abs(got-want) > 1e-6 {
+			t.Errorf("SumAdaptive(%d) mismatch: Go=%v, C=%v", n, want, got)
+		}
+	}
+
+	// Below the threshold but past capacity: pinned rather than truncated
+	tiny := NewVectorOps(100)
+	defer tiny.Close()
+	ones := make([]float64, 500)
+	for i := range ones {
+		ones[i] = 1
+	}
+	if got := tiny.SumAdaptive(ones); got != 500 {
+		t.Errorf("SumAdaptive(500 ones) with capacity 100 = %v, want 500", got)
+	}
+}
+
+func TestExpLogCorrectness(t *testing.T) {
+	ops := NewVectorOps(1000)
+	defer ops.Close()
+
+	if got := ops.Exp([]float64{0}); got[0] != 1 {
+		t.Errorf("Exp(0) = %v, want 1", got[0])
+	}
+	if got := ops.Log([]float64{1}); got[0] != 0 {
+		t.Errorf("Log(1) = %v, want 0", got[0])
+	}
+
+	special := ops.Log([]float64{0, -1})
+	if !math.IsInf(special[0], -1) {
+		t.Errorf("Log(0) = %v, want -Inf", special[0])
+	}
+	if !math.IsNaN(special[1]) {
+		t.Errorf("Log(-1) = %v, want NaN", special[1])
+	}
+
+	data := makeData(1000)
+	expGo, expC := GoExp(data), ops.Exp(data)
+	logGo, logC := GoLog(data), ops.Log(data)
+	for i := range data {
+		if math.Abs(expGo[i]-expC[i]) > 1e-12*expGo[i] {
+			t.Errorf("Exp[%d] mismatch: Go=%v, C=%v", i, expGo[i], expC[i])
+		}
+		if math.Abs(logGo[i]-logC[i]) > 1e-12 {
+			t.Errorf("Log[%d] mismatch: Go=%v, C=%v", i, logGo[i], logC[i])
+		}
+	}
+}
+
+func TestPowCorrectness(t *testing.T) {
+	ops := NewVectorOps(1000)
+	defer ops.Close()
+
+	data := makeData(1000)
+	squares := ops.Pow(data, 2)
+	roots := ops.Pow(data, 0.5)
+	cubes, cubesGo := ops.Pow(data, 3), GoPow(data, 3)
+	for i, x := range data {
+		if squares[i] != x*x {
+			t.Errorf("Pow(%v, 2) = %v, want %v", x, squares[i], x*x)
+		}
+		if math.Abs(roots[i]-math.Sqrt(x)) > 1e-12 {
+			t.Errorf("Pow(%v, 0.5) = %v, want %v", x, roots[i], math.Sqrt(x))
+		}
+		if math.Abs(cubes[i]-cubesGo[i]) > 1e-12*cubesGo[i] {
+			t.Errorf("Pow[%d] mismatch: Go=%v, C=%v", i, cubesGo[i], cubes[i])
+		}
+	}
+
+	if got := ops.Pow([]float64{-8}, -0.5); !math.IsNaN(got[0]) {
+		t.Errorf("Pow(-8, -0.5) = %v, want NaN", got[0])
+	}
+	if got := ops.Pow([]float64{2}, -2); got[0] != 0.25 {
+		t.Errorf("Pow(2, -2) = %v, want 0.25", got[0])
+	}
+	if got := ops.Pow([]float64{math.NaN()}, 0); got[0] != 1 {
+		t.Errorf("Pow(NaN, 0) = %v, want 1", got[0])
+	}
+}
+
+func TestSumTimed(t *testing.T) {
+	data := makeData(10000)
+
+	ops := NewVectorOps(len(data))
+	defer ops.Close()
+	result, copyDur, computeDur := ops.SumTimed(data)
+
+	if math.Abs(result-GoSum(data)) > 1e-6 {
+		t.Errorf("SumTimed mismatch: Go=%v, C=%v", GoSum(data), result)
+	}
+	if copyDur < 0 || computeDur < 0 {
+		t.Errorf("negative durations: copy=%v, compute=%v", copyDur, computeDur)
+	}
+}
+
+func TestReinit(t *testing.T) {
+	ops := NewVectorOps(100)
+	defer ops.Close()
+
+	_ = ops.Sum(makeData(100))
+	ops.Reinit(1000)
+
+	if ops.capacity != 1000 {
+		t.Fatalf("capacity after Reinit = %d, want 1000", ops.capacity)
+	}
+	for i, x := range ops.bufferA {
+		if x != 0 {
+			t.Fatalf("bufferA[%d] = %v after Reinit, want 0", i, x)
+		}
+	}
+
+	data := makeData(1000)
+	if got, want := ops.Sum(data), GoSum(data); math.Abs(got-want) > 1e-6 {
+		t.Errorf("Sum after Reinit mismatch: Go=%v, C=%v", want, got)
+	}
+}
+
+func TestCPUSupportsSIMD(t *testing.T) {
+	// The result depends on the machine; the call itself must be safe
+	t.Logf("CPUSupportsSIMD() = %v", CPUSupportsSIMD())
+}
+
+func TestInterleaveCorrectness(t *testing.T) {
+	ops := NewVectorOps(100)
+	defer ops.Close()
+
+	a := []float64{1, 2, 3}
+	b := []float64{10, 20, 30}
+	want := []float64{1, 10, 2, 20, 3, 30}
+
+	got := ops.Interleave(a, b)
+	if !slices.Equal(got, want) {
+		t.Errorf("Interleave = %v, want %v", got, want)
+	}
+	if goResult := GoInterleave(a, b); !slices.Equal(goResult, want) {
+		t.Errorf("GoInterleave = %v, want %v", goResult, want)
+	}
+
+	evens, odds := ops.Deinterleave(got)
+	if !slices.Equal(evens, a) || !slices.Equal(odds, b) {
+		t.Errorf("Deinterleave = %v, %v; want %v, %v", evens, odds, a, b)
+	}
+
+	// Odd length: the last element goes to evens
+	evens, odds = ops.Deinterleave([]float64{1, 2, 3})
+	goEvens, goOdds := GoDeinterleave([]float64{1, 2, 3})
+	if !slices.Equal(evens, []float64{1, 3}) || !slices.Equal(odds, []float64{2}) {
+		t.Errorf("Deinterleave(odd) = %v, %v; want [1 3], [2]", evens, odds)
+	}
+	if !slices.Equal(goEvens, evens) || !slices.Equal(goOdds, odds) {
+		t.Errorf("GoDeinterleave(odd) = %v, %v; want %v, %v", goEvens, goOdds, evens, odds)
+	}
+
+	// The 2n result is clamped to capacity
+	small := NewVectorOps(5)
+	defer small.Close()
+	if got := small.Interleave(a, b); len(got) != 4 {
+		t.Errorf("Interleave with capacity 5 returned %d elements, want 4", len(got))
+	}
+}
+
+func TestNewVectorOpsAligned(t *testing.T) {
+	data := makeData(1001)
+
+	ops := NewVectorOpsAligned(len(data))
+	defer ops.Close()
+
+	if ops.capacity != len(data) {
+		t.Errorf("capacity = %d, want %d", ops.capacity, len(data))
+	}
+	for name, buf := range map[string][]float64{"bufferA": ops.bufferA, "bufferB": ops.bufferB, "result": ops.result} {
+		if addr := uintptr(unsafe.Pointer(&buf[0])); addr%simdAlign != 0 {
+			t.Errorf("%s at %#x is not %d-byte aligned", name, addr, simdAlign)
+		}
+		if len(buf)%simdWidth != 0 {
+			t.Errorf("%s length %d is not a multiple of %d", name, len(buf), simdWidth)
+		}
+	}
+
+	if got, want := ops.SumSIMD(data), GoSum(data); math.Abs(got-want) > 1e-6 {
+		t.Errorf("SumSIMD mismatch: Go=%v, C=%v", want, got)
+	}
+	if got, want := ops.Dot(data, data), GoDot(data, data); math.Abs(got-want) > 1e-6*math.Abs(want) {
+		t.Errorf("Dot mismatch: Go=%v, C=%v", want, got)
+	}
+}
+
+func TestRunningMaxCorrectness(t *testing.T) {
+	// Noisy ramp: trending upward with dips
+	data := make([]float64, 1000)
+	for i := range data {
+		data[i] = float64(i) + rand.Float64()*50
+	}
+
+	ops := NewVectorOps(len(data))
+	defer ops.Close()
+	result := ops.RunningMax(data)
+
+	if !slices.Equal(result, GoRunningMax(data)) {
+		t.Error("RunningMax mismatch with Go reference")
+	}
+	for i := 1; i < len(result); i++ {
+		if result[i] < result[i-1] {
+			t.Fatalf("RunningMax decreases at %d: %v < %v", i, result[i], result[i-1])
+		}
+	}
+	if last := result[len(result)-1]; last != slices.Max(data) {
+		t.Errorf("RunningMax ends at %v, want global max %v", last, slices.Max(data))
+	}
+
+	if got := ops.RunningMax(nil); len(got) != 0 {
+		t.Errorf("RunningMax(nil) = %v, want empty", got)
+	}
+}
+
+func TestMulPooled(t *testing.T) {
+	a := makeData(1000)
+	b := makeData(1000)
+
+	ops := NewVectorOps(len(a))
+	defer ops.Close()
+
+	want := GoMul(a, b)
+	first, release := ops.MulPooled(a, b)
+	if !slices.Equal(first, want) {
+		t.Fatal("MulPooled mismatch with Go reference")
+	}
+	firstAddr := &first[0]
+	release()
+
+	// A released slice is handed out again (the pool may drop items, so
+	// allow a few attempts)
+	reused := false
+	for i := 0; i < 10 && !reused; i++ {
+		result, release := ops.MulPooled(b, a)
+		if !slices.Equal(result, want) {
+			t.Fatal("MulPooled mismatch after reuse")
+		}
+		reused = &result[0] == firstAddr
+		release()
+	}
+	if !reused {
+		t.Error("MulPooled never reused a released slice")
+	}
+}
+
+func TestGreaterThanCorrectness(t *testing.T) {
+	ops := NewVectorOps(1000)
+	defer ops.Close()
+
+	data := []float64{1, 5, 3, 8, 5, math.NaN(), 10}
+	want := []bool{false, false, false, true, false, false, true}
+
+	if got := ops.GreaterThan(data, 5); !slices.Equal(got, want) {
+		t.Errorf("GreaterThan = %v, want %v", got, want)
+	}
+	if got := ops.CountGreater(data, 5); got != 2 {
+		t.Errorf("CountGreater = %d, want 2", got)
+	}
+
+	random := makeData(1000)
+	if got, want := ops.GreaterThan(random, 50), GoGreaterThan(random, 50); !slices.Equal(got, want) {
+		t.Error("GreaterThan mismatch with Go reference")
+	}
+	if got, want := ops.CountGreater(random, 50), GoCountGreater(random, 50); got != want {
+		t.Errorf("CountGreater mismatch: Go=%d, C=%d", want, got)
+	}
+}
+
+func TestPrefetchKeepsResults(t *testing.T) {
+	ops := NewVectorOps(1000)
+	defer ops.Close()
+
+	a, b := makeData(1000), makeData(1000)
+	sum, dot, mul := ops.Sum(a), ops.Dot(a, b), ops.Mul(a, b)
+
+	ops.Prefetch(a)
+	ops.Prefetch(makeData(2000)) // clamped to capacity
+	ops.Prefetch(nil)
+
+	if got := ops.Sum(a); got != sum {
+		t.Errorf("Sum after Prefetch = %v, want %v", got, sum)
+	}
+	if got := ops.Dot(a, b); got != dot {
+		t.Errorf("Dot after Prefetch = %v, want %v", got, dot)
+	}
+	if got := ops.Mul(a, b); !slices.Equal(got, mul) {
+		t.Error("Mul after Prefetch changed the result")
+	}
+}
+
+func TestAddSatCorrectness(t *testing.T) {
+	ops := NewVectorOps(1000)
+	defer ops.Close()
+
+	a := []float64{0.5, 0.75, -0.75, -1, 0.25}
+	b := []float64{0.25, 0.5, -0.5, -1, math.NaN()}
+	want := []float64{0.75, 1, -1, -1}
+	got := ops.AddSat(a, b, -1, 1)
+	if !slices.Equal(got[:4], want) || !math.IsNaN(got[4]) {
+		t.Errorf("AddSat = %v, want %v followed by NaN", got, want)
+	}
+
+	x, y := makeData(1000), makeData(1000)
+	if got, want := ops.AddSat(x, y, 50, 150), GoAddSat(x, y, 50, 150); !slices.Equal(got, want) {
+		t.Error("AddSat mismatch with Go reference")
+	}
+}
+
+func TestVectorOpsUnsafe(t *testing.T) {
+	ops := NewVectorOpsUnsafe(1000)
+	defer ops.Close()
+
+	a, b := makeData(1000), makeData(1000)
+	if got, want := ops.Sum(a), GoSum(a); math.Abs(got-want) > 1e-6 {
+		t.Errorf("Sum mismatch: Go=%v, C=%v", want, got)
+	}
+	if got, want := ops.Dot(a, b), GoDot(a, b); math.Abs(got-want) > 1e-6 {
+		t.Errorf("Dot mismatch: Go=%v, C=%v", want, got)
+	}
+
+	ops.Reinit(2000)
+	data := makeData(2000)
+	if got, want := ops.Sum(data), GoSum(data); math.Abs(got-want) > 1e-6 {
+		t.Errorf("Sum after Reinit mismatch: Go=%v, C=%v", want, got)
+	}
+}
+
+func TestSumNTruncation(t *testing.T) {
+	ops := NewVectorOps(100)
+	defer ops.Close()
+
+	data := makeData(150)
+	sum, processed := ops.SumN(data)
+	if processed != 100 {
+		t.Errorf("SumN processed = %d, want 100", processed)
+	}
+	if want := GoSum(data[:100]); math.Abs(sum-want) > 1e-9 {
+		t.Errorf("SumN = %v, want %v", sum, want)
+	}
+	if _, processed := ops.SumN(data[:50]); processed != 50 {
+		t.Errorf("SumN processed = %d, want 50", processed)
+	}
+
+	dot, processed := ops.DotN(data, data)
+	if processed != 100 {
+		t.Errorf("DotN processed = %d, want 100", processed)
+	}
+	if want := GoDot(data[:100], data[:100]); math.Abs(dot-want) > 1e-6 {
+		t.Errorf("DotN = %v, want %v", dot, want)
+	}
+	if _, processed := ops.DotN(data, data[:10]); processed != 0 {
+		t.Errorf("DotN with short b processed = %d, want 0", processed)
+	}
+}
+
+func TestScaleRows(t *testing.T) {
+	ops := NewVectorOps(100)
+	defer ops.Close()
+
+	matrix := []float64{
+		1, 2,
+		3, 4,
+		5, 6,
+	}
+	want := []float64{
+		2, 4,
+		-3, -4,
+		0.5, 0.6,
+	}
+	if !ops.ScaleRows(matrix, 3, 2, []float64{2, -1, 0.1}) {
+		t.Fatal("ScaleRows rejected a valid 3x2 matrix")
+	}
+	for i := range want {
+		if math.Abs(matrix[i]-want[i]) > 1e-12 {
+			t.Errorf("ScaleRows = %v, want %v", matrix, want)
+			break
+		}
+	}
+
+	goMatrix := slices.Clone(want)
+	GoScaleRows(goMatrix, 3, 2, []float64{3, 3, 3})
+	ops.ScaleRows(want, 3, 2, []float64{3, 3, 3})
+	if !slices.Equal(goMatrix, want) {
+		t.Errorf("ScaleRows mismatch: Go=%v, C=%v", goMatrix, want)
+	}
+
+	// Shape mismatches leave the matrix alone
+	orig := []float64{1, 2, 3, 4, 5, 6}
+	bad := slices.Clone(orig)
+	if ops.ScaleRows(bad, 2, 2, []float64{2, 2}) || ops.ScaleRows(bad, 3, 2, []float64{2, 2}) {
+		t.Error("ScaleRows accepted mismatched dimensions")
+	}
+	if ops.ScaleRows(make([]float64, 200), 100, 2, make([]float64, 100)) {
+		t.Error("ScaleRows accepted a matrix larger than capacity")
+	}
+	if !slices.Equal(bad, orig) {
+		t.Errorf("rejected ScaleRows modified the matrix: %v", bad)
+	}
+}
+
+func TestOuterCorrectness(t *testing.T) {
+	ops := NewVectorOps(100)
+	defer ops.Close()
+
+	a := []float64{1, 2}
+	b := []float64{3, 4, 5}
+	want := []float64{
+		3, 4, 5,
+		6, 8, 10,
+	}
+	if got := ops.Outer(a, b); !slices.Equal(got, want) {
+		t.Errorf("Outer = %v, want %v", got, want)
+	}
+	if got := GoOuter(a, b); !slices.Equal(got, want) {
+		t.Errorf("GoOuter = %v, want %v", got, want)
+	}
+
+	if got := ops.Outer(makeData(10), makeData(11)); got != nil {
+		t.Errorf("Outer over capacity = %d elements, want nil", len(got))
+	}
+	if got := ops.Outer(nil, b); got != nil {
+		t.Errorf("Outer(nil, b) = %v, want nil", got)
+	}
+}
+
+func TestDotExtendedPrecision(t *testing.T) {
+	ops := NewVectorOps(1000)
+	defer ops.Close()
+
+	// 1e16 + 1 rounds back to 1e16, so the naive sum loses the 1
+	a := []float64{1e16, 1, -1e16, 3}
+	b := []float64{1, 1, 1, 1}
+	if got := GoDot(a, b); got == 4 {
+		t.Fatalf("naive dot = %v, expected it to lose precision", got)
+	}
+	if got := ops.DotExtended(a, b); got != 4 {
+		t.Errorf("DotExtended = %v, want 4", got)
+	}
+
+	// Products of mixed magnitude that nearly cancel, against an exact
+	// big.Float reference
+	x, y := make([]float64, 1000), make([]float64, 1000)
+	for i := range x {
+		x[i] = (rand.Float64() - 0.5) * math.Pow(10, float64(rand.Intn(16)))
+		y[i] = rand.Float64() - 0.5
+	}
+	x = append(x, x...)
+	y = append(y, y...)
+	for i := len(y) / 2; i < len(y); i++ {
+		y[i] = -y[i]
+	}
+	// The first pair no longer cancels, leaving a result of -y[0]
+	x[0], x[len(x)/2] = 1, 2
+	ops.Reinit(len(x))
+
+	exact := new(big.Float).SetPrec(2048)
+	for i := range x {
+		exact.Add(exact, new(big.Float).SetPrec(2048).Mul(big.NewFloat(x[i]), big.NewFloat(y[i])))
+	}
+	want, _ := exact.Float64()
+
+	extErr := math.Abs(ops.DotExtended(x, y) - want)
+	naiveErr := math.Abs(GoDot(x, y) - want)
+	if extErr > naiveErr {
+		t.Errorf("DotExtended error %g exceeds naive error %g", extErr, naiveErr)
+	}
+	if extErr > 1e-12*math.Abs(want) {
+		t.Errorf("DotExtended error %g too large for result %g", extErr, want)
+	}
+}
+
+func TestGeometricMean(t *testing.T) {
+	ops := NewVectorOps(1000)
+	defer ops.Close()
+
+	small := []float64{1, 2, 4, 8, 0.5}
+	product := 1.0
+	for _, v := range small {
+		product *= v
+	}
+	want := math.Pow(product, 1/float64(len(small)))
+	if got := ops.GeometricMean(small); math.Abs(got-want) > 1e-12 {
+		t.Errorf("GeometricMean(%v) = %v, want %v", small, got, want)
+	}
+
+	// The direct product would overflow
+	huge := make([]float64, 1000)
+	for i := range huge {
+		huge[i] = 1e300
+	}
+	if got := ops.GeometricMean(huge); math.Abs(got-1e300) > 1e290 {
+		t.Errorf("GeometricMean(1e300 x 1000) = %v, want 1e300", got)
+	}
+
+	data := makeData(1000)
+	if got, want := ops.GeometricMean(data), GoGeometricMean(data); math.Abs(got-want) > 1e-9*want {
+		t.Errorf("GeometricMean mismatch: Go=%v, C=%v", want, got)
+	}
+
+	for _, bad := range [][]float64{{1, 0, 2}, {1, -2}, {math.NaN()}, nil} {
+		if got := ops.GeometricMean(bad); !math.IsNaN(got) {
+			t.Errorf("GeometricMean(%v) = %v, want NaN", bad, got)
+		}
+	}
+}
+
+func TestDotReproducible(t *testing.T) {
+	ops := NewVectorOps(10000)
+	defer ops.Close()
+
+	for _, n := range []int{1, 2, 3, 7, 1000, 9999} {
+		a, b := make([]float64, n), make([]float64, n)
+		for i := range a {
+			a[i] = (rand.Float64() - 0.5) * math.Pow(10, float64(rand.Intn(8)))
+			b[i] = rand.Float64() - 0.5
+		}
+
+		first := ops.DotReproducible(a, b)
+		for i := 0; i < 5; i++ {
+			if got := ops.DotReproducible(a, b); math.Float64bits(got) != math.Float64bits(first) {
+				t.Fatalf("n=%d: call %d returned %v, first call %v", n, i, got, first)
+			}
+		}
+		if want := GoDotReproducible(a, b); math.Float64bits(first) != math.Float64bits(want) {
+			t.Errorf("n=%d: DotReproducible = %v, reference tree = %v", n, first, want)
+		}
+		if want := GoDot(a, b); math.Abs(first-want) > 1e-9*math.Max(1, math.Abs(want)) {
+			t.Errorf("n=%d: DotReproducible = %v, GoDot = %v", n, first, want)
+		}
+	}
+}
+
+// TestBuildVariant passes under both the default and the portable build tag
+func TestBuildVariant(t *testing.T) {
+	t.Logf("PortableBuild = %v", PortableBuild)
+
+	ops := NewVectorOps(10000)
+	defer ops.Close()
+
+	data := makeData(9999)
+	sum, simd, want := ops.Sum(data), ops.SumSIMD(data), GoSum(data)
+	if math.Abs(sum-want) > 1e-6 || math.Abs(simd-want) > 1e-6 {
+		t.Errorf("Sum = %v, SumSIMD = %v, want %v", sum, simd, want)
+	}
+	// The portable SumSIMD is the scalar kernel, so it matches Sum exactly
+	if PortableBuild && simd != sum {
+		t.Errorf("portable SumSIMD = %v, want exactly Sum = %v", simd, sum)
+	}
+	if !CPUSupportsSIMD() {
+		t.Errorf("CPUSupportsSIMD() = false on the build machine")
+	}
+}
+
+func TestMeanStdDevWelford(t *testing.T) {
+	ops := NewVectorOps(1000)
+	defer ops.Close()
+
+	// Deviations of 4, 7, 13, 16 around a 1e9 offset: mean 1e9+10, variance 22.5
+	data := []float64{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16}
+	wantMean, wantStd := 1e9+10, math.Sqrt(22.5)
+
+	// The naive sum-of-squares formula loses the variance in the offset
+	var sum, sumSq float64
+	for _, x := range data {
+		sum += x
+		sumSq += x * x
+	}
+	naiveMean := sum / float64(len(data))
+	naiveStd := math.Sqrt(math.Abs(sumSq/float64(len(data)) - naiveMean*naiveMean))
+	if math.Abs(naiveStd-wantStd) < 1e-6 {
+		t.Fatalf("naive std = %v, expected it to lose precision", naiveStd)
+	}
+
+	mean, std := ops.MeanStdDev(data)
+	if math.Abs(mean-wantMean) > 1e-6 || math.Abs(std-wantStd) > 1e-6 {
+		t.Errorf("MeanStdDev = (%v, %v), want (%v, %v)", mean, std, wantMean, wantStd)
+	}
+	if got := ops.StdDev(data); got != std {
+		t.Errorf("StdDev = %v, want %v", got, std)
+	}
+	if goMean, goStd := GoMeanStdDev(data); goMean != mean || math.Abs(goStd-std) > 1e-12 {
+		t.Errorf("Go reference = (%v, %v), C = (%v, %v)", goMean, goStd, mean, std)
+	}
+
+	if m, s := ops.MeanStdDev(nil); !math.IsNaN(m) || !math.IsNaN(s) {
+		t.Errorf("MeanStdDev(nil) = (%v, %v), want NaN", m, s)
+	}
+}
+
+func TestNormalizeRows(t *testing.T) {
+	ops := NewVectorOps(1000)
+	defer ops.Close()
+
+	const rows, cols = 10, 37
+	matrix := makeData(rows * cols)
+	for c := 0; c < cols; c++ {
+		matrix[3*cols+c] = 0 // a zero row stays zero
+	}
+	want := append([]float64(nil), matrix...)
+	GoNormalizeRows(want, rows, cols)
+
+	if !ops.NormalizeRows(matrix, rows, cols) {
+		t.Fatal("NormalizeRows rejected a valid matrix")
+	}
+	for r := 0; r < rows; r++ {
+		row := matrix[r*cols : (r+1)*cols]
+		norm := math.Sqrt(GoDot(row, row))
+		if r == 3 {
+			if norm != 0 {
+				t.Errorf("zero row has norm %v after NormalizeRows", norm)
+			}
+			continue
+		}
+		if math.Abs(norm-1) > 1e-12 {
+			t.Errorf("row %d norm = %v, want 1", r, norm)
+		}
+	}
+	for i := range matrix {
+		if math.Abs(matrix[i]-want[i]) > 1e-12 {
+			t.Fatalf("NormalizeRows[%d] = %v, Go reference %v", i, matrix[i], want[i])
+		}
+	}
+
+	if ops.NormalizeRows(make([]float64, 10), 3, 3) {
+		t.Error("NormalizeRows accepted len(matrix) != rows*cols")
+	}
+	if ops.NormalizeRows(make([]float64, 2000), 40, 50) {
+		t.Error("NormalizeRows accepted a matrix larger than capacity")
+	}
+}
+
 // --- Benchmarks ---
 
 // BenchmarkSum compares sum implementations
@@ -95,6 +884,25 @@ func BenchmarkSum_C_Direct_1000(b *testing.B)   { benchmarkCDirect(b, 1000) }
 func BenchmarkSum_C_Direct_10000(b *testing.B)  { benchmarkCDirect(b, 10000) }
 func BenchmarkSum_C_Direct_100000(b *testing.B) { benchmarkCDirect(b, 100000) }
 
+// Adaptive sum should track Optimized below DefaultPinThreshold and Direct above it
+func BenchmarkSum_C_Adaptive_100(b *testing.B)    { benchmarkCSumAdaptive(b, 100) }
+func BenchmarkSum_C_Adaptive_1000(b *testing.B)   { benchmarkCSumAdaptive(b, 1000) }
+func BenchmarkSum_C_Adaptive_10000(b *testing.B)  { benchmarkCSumAdaptive(b, 10000) }
+func BenchmarkSum_C_Adaptive_100000(b *testing.B) { benchmarkCSumAdaptive(b, 100000) }
+
+// Prefetch warms the input buffer before timing; compare against Optimized
+func BenchmarkSum_C_Prefetch_100(b *testing.B)    { benchmarkCSumPrefetch(b, 100) }
+func BenchmarkSum_C_Prefetch_1000(b *testing.B)   { benchmarkCSumPrefetch(b, 1000) }
+func BenchmarkSum_C_Prefetch_10000(b *testing.B)  { benchmarkCSumPrefetch(b, 10000) }
+func BenchmarkSum_C_Prefetch_100000(b *testing.B) { benchmarkCSumPrefetch(b, 100000) }
+
+// Locked vs Unlocked isolates the per-call mutex cost, which matters most for
+// small inputs
+func BenchmarkSum_C_Locked_100(b *testing.B)     { benchmarkCSumLocking(b, 100, false) }
+func BenchmarkSum_C_Unlocked_100(b *testing.B)   { benchmarkCSumLocking(b, 100, true) }
+func BenchmarkSum_C_Locked_10000(b *testing.B)   { benchmarkCSumLocking(b, 10000, false) }
+func BenchmarkSum_C_Unlocked_10000(b *testing.B) { benchmarkCSumLocking(b, 10000, true) }
+
 func benchmarkGoSum(b *testing.B, n int) {
 	data := makeData(n)
 	b.ResetTimer()
@@ -133,6 +941,18 @@ func benchmarkCSumSIMD(b *testing.B, n int) {
 	}
 }
 
+func benchmarkCSumPrefetch(b *testing.B, n int) {
+	data := makeData(n)
+	ops := NewVectorOps(n)
+	defer ops.Close()
+	ops.Prefetch(data)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = ops.Sum(data)
+	}
+}
+
 func benchmarkCDirect(b *testing.B, n int) {
 	data := makeData(n)
 	b.ResetTimer()
@@ -141,6 +961,33 @@ func benchmarkCDirect(b *testing.B, n int) {
 	}
 }
 
+func benchmarkCSumAdaptive(b *testing.B, n int) {
+	data := makeData(n)
+	ops := NewVectorOps(n)
+	defer ops.Close()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = ops.SumAdaptive(data)
+	}
+}
+
+func benchmarkCSumLocking(b *testing.B, n int, unlocked bool) {
+	data := makeData(n)
+	var ops *VectorOps
+	if unlocked {
+		ops = NewVectorOpsUnsafe(n)
+	} else {
+		ops = NewVectorOps(n)
+	}
+	defer ops.Close()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = ops.Sum(data)
+	}
+}
+
 // BenchmarkDot compares dot product implementations
 func BenchmarkDot_Go_1000(b *testing.B)      { benchmarkGoDot(b, 1000) }
 func BenchmarkDot_Go_10000(b *testing.B)     { benchmarkGoDot(b, 10000) }
@@ -0,0 +1,47 @@
+package ffi
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+// SumFile sums a file of little-endian float64 values without loading it
+// into memory: the file is read in chunks the size of ops' capacity and each
+// chunk is summed with ops.Sum. It fails if the file size is not a multiple
+// of 8 bytes.
+func SumFile(ops *VectorOps, path string) (float64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	chunk := make([]float64, max(ops.capacity, 1))
+	raw := make([]byte, 8*len(chunk))
+
+	var total float64
+	var offset int64
+	for {
+		n, err := io.ReadFull(f, raw)
+		if n%8 != 0 {
+			return 0, fmt.Errorf("%s: trailing %d bytes at offset %d are not a whole float64", path, n%8, offset+int64(n-n%8))
+		}
+		count := n / 8
+		for i := range count {
+			chunk[i] = math.Float64frombits(binary.LittleEndian.Uint64(raw[i*8:]))
+		}
+		total += ops.Sum(chunk[:count])
+		offset += int64(n)
+
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return total, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+}
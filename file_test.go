@@ -0,0 +1,50 @@
+package ffi
+
+import (
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeFloats writes data as little-endian float64 values, followed by extra
+// raw bytes, and returns the file path.
+func writeFloats(t *testing.T, data []float64, extra ...byte) string {
+	buf := make([]byte, 0, 8*len(data)+len(extra))
+	for _, v := range data {
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(v))
+	}
+	buf = append(buf, extra...)
+
+	path := filepath.Join(t.TempDir(), "data.bin")
+	if err := os.WriteFile(path, buf, 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	return path
+}
+
+func TestSumFile(t *testing.T) {
+	// Capacity 64 forces several chunks plus a short final one
+	ops := NewVectorOps(64)
+	defer ops.Close()
+
+	data := makeData(1000)
+	got, err := SumFile(ops, writeFloats(t, data))
+	if err != nil {
+		t.Fatalf("SumFile failed: %v", err)
+	}
+	if want := GoSum(data); math.Abs(got-want) > 1e-6 {
+		t.Errorf("SumFile = %v, want %v", got, want)
+	}
+
+	if got, err := SumFile(ops, writeFloats(t, nil)); err != nil || got != 0 {
+		t.Errorf("SumFile(empty) = (%v, %v), want (0, nil)", got, err)
+	}
+	if _, err := SumFile(ops, writeFloats(t, data[:10], 1, 2, 3)); err == nil {
+		t.Error("SumFile accepted a file with a partial trailing value")
+	}
+	if _, err := SumFile(ops, filepath.Join(t.TempDir(), "missing.bin")); err == nil {
+		t.Error("SumFile succeeded on a missing file")
+	}
+}
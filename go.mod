@@ -2,4 +2,7 @@ module github.com/paulstuart/cgo-ffi
 
 go 1.25.4
 
-require github.com/bytecodealliance/wasmtime-go/v39 v39.0.1
+require (
+	github.com/bytecodealliance/wasmtime-go/v39 v39.0.1
+	github.com/tetratelabs/wazero v1.9.0
+)
@@ -0,0 +1,63 @@
+package ffi
+
+/*
+#include "vector.h"
+*/
+import "C"
+
+import (
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+// IntVectorOps provides C-backed operations on int32 vectors, using the same
+// pre-allocated, pinned buffer pattern as VectorOps.
+type IntVectorOps struct {
+	buffer []int32
+	pinner runtime.Pinner
+	ptr    *C.int32_t
+
+	capacity int
+
+	mu sync.Mutex
+}
+
+// NewIntVectorOps creates a new IntVectorOps with a pre-allocated buffer.
+func NewIntVectorOps(capacity int) *IntVectorOps {
+	v := &IntVectorOps{
+		buffer:   make([]int32, capacity),
+		capacity: capacity,
+	}
+
+	v.pinner.Pin(&v.buffer[0])
+	v.ptr = (*C.int32_t)(unsafe.Pointer(&v.buffer[0]))
+
+	return v
+}
+
+// Close releases pinned memory. Must be called when done.
+func (v *IntVectorOps) Close() {
+	v.pinner.Unpin()
+}
+
+// ScaleSat multiplies all elements by a scalar in-place, saturating at the
+// int32 limits instead of wrapping on overflow.
+func (v *IntVectorOps) ScaleSat(data []int32, scalar int32) {
+	n := len(data)
+	if n == 0 {
+		return
+	}
+	if n > v.capacity {
+		n = v.capacity
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	copy(v.buffer[:n], data[:n])
+
+	C.vector_scale_i32_sat(v.ptr, C.int32_t(scalar), C.size_t(n))
+
+	copy(data[:n], v.buffer[:n])
+}
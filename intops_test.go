@@ -0,0 +1,33 @@
+package ffi
+
+import (
+	"math"
+	"testing"
+)
+
+func TestScaleSatCorrectness(t *testing.T) {
+	data := []int32{1, -1, 1 << 20, -(1 << 20), math.MaxInt32, 0, 12345}
+	want := append([]int32(nil), data...)
+	GoScaleSatInt32(want, 1<<16)
+
+	ops := NewIntVectorOps(len(data))
+	defer ops.Close()
+	ops.ScaleSat(data, 1<<16)
+
+	for i := range want {
+		if data[i] != want[i] {
+			t.Errorf("ScaleSat mismatch at %d: Go=%d, C=%d", i, want[i], data[i])
+		}
+	}
+
+	// 2^20 * 2^16 overflows int32 and must clamp rather than wrap
+	if data[2] != math.MaxInt32 {
+		t.Errorf("ScaleSat(2^20) = %d, want %d", data[2], int32(math.MaxInt32))
+	}
+	if data[3] != math.MinInt32 {
+		t.Errorf("ScaleSat(-2^20) = %d, want %d", data[3], int32(math.MinInt32))
+	}
+	if data[6] != 12345<<16 {
+		t.Errorf("ScaleSat(12345) = %d, want %d", data[6], 12345<<16)
+	}
+}
@@ -10,10 +10,10 @@ package main
 
 import (
 	"fmt"
-	"math/rand"
 	"slices"
 	"time"
 
+	"github.com/paulstuart/cgo-ffi/matcher"
 	gomatcher "github.com/paulstuart/cgo-ffi/matcher/go"
 	"github.com/paulstuart/cgo-ffi/matcher/testdata"
 	"github.com/paulstuart/cgo-ffi/matcher/vectorscan"
@@ -28,54 +28,13 @@ type Matcher interface {
 	Close()
 }
 
-// stats holds timing statistics for a benchmark
-type stats struct {
-	min   time.Duration
-	avg   time.Duration
-	p95   time.Duration
-	max   time.Duration
-	total time.Duration
-	n     int
-}
-
-// benchmark runs a function n times and collects timing statistics
-func benchmark(n int, fn func()) stats {
-	times := make([]time.Duration, n)
-
-	for i := 0; i < n; i++ {
-		start := time.Now()
-		fn()
-		times[i] = time.Since(start)
-	}
-
-	slices.Sort(times)
-
-	var total time.Duration
-	for _, t := range times {
-		total += t
-	}
-
-	p95idx := int(float64(n) * 0.95)
-	if p95idx >= n {
-		p95idx = n - 1
-	}
-
-	return stats{
-		min:   times[0],
-		avg:   total / time.Duration(n),
-		p95:   times[p95idx],
-		max:   times[n-1],
-		total: total,
-		n:     n,
-	}
-}
-
-func (s stats) String() string {
+// formatStats formats match latency statistics for a table row
+func formatStats(s matcher.LatencyStats) string {
 	return fmt.Sprintf("avg=%-8s min=%-8s p95=%-8s max=%-8s",
-		formatDuration(s.avg),
-		formatDuration(s.min),
-		formatDuration(s.p95),
-		formatDuration(s.max))
+		formatDuration(s.Avg),
+		formatDuration(s.Min),
+		formatDuration(s.P95),
+		formatDuration(s.Max))
 }
 
 func formatDuration(d time.Duration) string {
@@ -119,102 +78,51 @@ func runComparison(patternCount int) {
 	fmt.Printf("└──────────────────────────────────────────────────────────────────────────────┘\n")
 
 	patterns := testdata.MalwarePatterns[:patternCount]
-	benignFiles := testdata.BenignFilenames()
 	iterations := 1000
 
-	// === Pure Go Matcher ===
-	fmt.Println("\n  ┌─ Pure Go (sequential matching) ─────────────────────────────────────────┐")
-
-	goStart := time.Now()
-	goMatcher, err := gomatcher.NewGoMatcher(patterns)
+	report, err := matcher.RunBackendComparison(patterns, testdata.TestFilenames, iterations)
 	if err != nil {
-		fmt.Printf("  │ ERROR: %v\n", err)
-	} else {
-		defer goMatcher.Close()
-		fmt.Printf("  │ Compile time: %v\n", time.Since(goStart))
-
-		firstHit, middleHit, lastHit := findHitPositions(goMatcher, patternCount)
-
-		if firstHit != "" {
-			s := benchmark(iterations, func() { goMatcher.Match(firstHit) })
-			fmt.Printf("  │ First hit:    %s\n", s)
-		}
-		if middleHit != "" {
-			s := benchmark(iterations, func() { goMatcher.Match(middleHit) })
-			fmt.Printf("  │ Middle hit:   %s\n", s)
-		}
-		if lastHit != "" {
-			s := benchmark(iterations, func() { goMatcher.Match(lastHit) })
-			fmt.Printf("  │ Last hit:     %s\n", s)
-		}
-		if len(benignFiles) > 0 {
-			benign := benignFiles[rand.Intn(len(benignFiles))]
-			s := benchmark(iterations, func() { goMatcher.Match(benign) })
-			fmt.Printf("  │ No match:     %s\n", s)
-		}
-
-		// Batch scan
-		var goMatches int
-		batchStart := time.Now()
-		for _, f := range testdata.TestFilenames {
-			if goMatcher.Match(f) >= 0 {
-				goMatches++
-			}
-		}
-		batchTime := time.Since(batchStart)
-		fmt.Printf("  │ Scan all:     %v (%d files, %d matches, %.0f files/sec)\n",
-			batchTime, len(testdata.TestFilenames), goMatches,
-			float64(len(testdata.TestFilenames))/batchTime.Seconds())
+		fmt.Printf("  ERROR: %v\n", err)
+		return
 	}
-	fmt.Println("  └────────────────────────────────────────────────────────────────────────────┘")
 
-	// === Vectorscan Matcher ===
-	fmt.Println("\n  ┌─ Vectorscan (simultaneous matching) ────────────────────────────────────┐")
+	titles := map[matcher.Kind]string{
+		matcher.KindGo:         "\n  ┌─ Pure Go (sequential matching) ─────────────────────────────────────────┐",
+		matcher.KindVectorscan: "\n  ┌─ Vectorscan (simultaneous matching) ────────────────────────────────────┐",
+	}
 
-	vsStart := time.Now()
-	vsMatcher, err := vectorscan.NewVsMatcher(patterns)
-	if err != nil {
-		fmt.Printf("  │ ERROR: %v\n", err)
-	} else {
-		defer vsMatcher.Close()
-		compileTime := time.Since(vsStart)
-		dbSize, _ := vsMatcher.DatabaseSize()
-		fmt.Printf("  │ Compile time: %v (database: %.1f KB)\n", compileTime, float64(dbSize)/1024)
-
-		firstHit, middleHit, lastHit := findVsHitPositions(vsMatcher, patternCount)
-
-		if firstHit != "" {
-			s := benchmark(iterations, func() { vsMatcher.Match(firstHit) })
-			fmt.Printf("  │ First hit:    %s\n", s)
-		}
-		if middleHit != "" {
-			s := benchmark(iterations, func() { vsMatcher.Match(middleHit) })
-			fmt.Printf("  │ Middle hit:   %s\n", s)
-		}
-		if lastHit != "" {
-			s := benchmark(iterations, func() { vsMatcher.Match(lastHit) })
-			fmt.Printf("  │ Last hit:     %s\n", s)
+	for _, r := range report.Backends {
+		fmt.Println(titles[r.Kind])
+		if r.Err != nil {
+			fmt.Printf("  │ ERROR: %v\n", r.Err)
+			fmt.Println("  └────────────────────────────────────────────────────────────────────────────┘")
+			continue
 		}
-		if len(benignFiles) > 0 {
-			benign := benignFiles[rand.Intn(len(benignFiles))]
-			s := benchmark(iterations, func() { vsMatcher.Match(benign) })
-			fmt.Printf("  │ No match:     %s\n", s)
+
+		if r.DatabaseSize > 0 {
+			fmt.Printf("  │ Compile time: %v (database: %.1f KB)\n", r.CompileTime, float64(r.DatabaseSize)/1024)
+		} else {
+			fmt.Printf("  │ Compile time: %v\n", r.CompileTime)
 		}
 
-		// Batch scan
-		var vsMatches int
-		batchStart := time.Now()
-		for _, f := range testdata.TestFilenames {
-			if vsMatcher.Match(f) >= 0 {
-				vsMatches++
+		for _, row := range []struct {
+			label string
+			s     matcher.LatencyStats
+		}{
+			{"First hit:", r.FirstHit},
+			{"Middle hit:", r.MiddleHit},
+			{"Last hit:", r.LastHit},
+			{"No match:", r.NoMatch},
+		} {
+			if row.s.N > 0 {
+				fmt.Printf("  │ %-13s %s\n", row.label, formatStats(row.s))
 			}
 		}
-		batchTime := time.Since(batchStart)
+
 		fmt.Printf("  │ Scan all:     %v (%d files, %d matches, %.0f files/sec)\n",
-			batchTime, len(testdata.TestFilenames), vsMatches,
-			float64(len(testdata.TestFilenames))/batchTime.Seconds())
+			r.ScanTime, report.Inputs, r.Matches, r.Throughput)
+		fmt.Println("  └────────────────────────────────────────────────────────────────────────────┘")
 	}
-	fmt.Println("  └────────────────────────────────────────────────────────────────────────────┘")
 
 	fmt.Println()
 }
@@ -314,82 +222,6 @@ func runThroughputComparison() {
 	fmt.Println()
 }
 
-// findHitPositions finds test files that match patterns at different positions (Go matcher)
-func findHitPositions(m *gomatcher.GoMatcher, patternCount int) (first, middle, last string) {
-	type match struct {
-		file       string
-		patternIdx int
-	}
-	var matches []match
-
-	for _, idx := range testdata.MaliciousIndices {
-		if idx >= len(testdata.TestFilenames) {
-			continue
-		}
-		f := testdata.TestFilenames[idx]
-		matchIdx := m.Match(f)
-		if matchIdx >= 0 && matchIdx < patternCount {
-			matches = append(matches, match{f, matchIdx})
-		}
-	}
-
-	if len(matches) == 0 {
-		return "", "", ""
-	}
-
-	slices.SortFunc(matches, func(a, b match) int {
-		return a.patternIdx - b.patternIdx
-	})
-
-	first = matches[0].file
-	if len(matches) > 1 {
-		last = matches[len(matches)-1].file
-	}
-	if len(matches) > 2 {
-		middle = matches[len(matches)/2].file
-	}
-
-	return first, middle, last
-}
-
-// findVsHitPositions finds test files that match patterns at different positions (Vectorscan matcher)
-func findVsHitPositions(m *vectorscan.VsMatcher, patternCount int) (first, middle, last string) {
-	type match struct {
-		file       string
-		patternIdx int
-	}
-	var matches []match
-
-	for _, idx := range testdata.MaliciousIndices {
-		if idx >= len(testdata.TestFilenames) {
-			continue
-		}
-		f := testdata.TestFilenames[idx]
-		matchIdx := m.Match(f)
-		if matchIdx >= 0 && matchIdx < patternCount {
-			matches = append(matches, match{f, matchIdx})
-		}
-	}
-
-	if len(matches) == 0 {
-		return "", "", ""
-	}
-
-	slices.SortFunc(matches, func(a, b match) int {
-		return a.patternIdx - b.patternIdx
-	})
-
-	first = matches[0].file
-	if len(matches) > 1 {
-		last = matches[len(matches)-1].file
-	}
-	if len(matches) > 2 {
-		middle = matches[len(matches)/2].file
-	}
-
-	return first, middle, last
-}
-
 func runWasmComparison() {
 	fmt.Printf("╔══════════════════════════════════════════════════════════════════════════════╗\n")
 	fmt.Printf("║  WASM VECTORSCAN COMPARISON (literal patterns only)                          ║\n")
@@ -0,0 +1,167 @@
+package matcher
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	gomatcher "github.com/paulstuart/cgo-ffi/matcher/go"
+	"github.com/paulstuart/cgo-ffi/matcher/vectorscan"
+)
+
+// LatencyStats summarizes repeated timings of a single Match call.
+// N is zero when there was no input to time.
+type LatencyStats struct {
+	Min time.Duration
+	Avg time.Duration
+	P95 time.Duration
+	Max time.Duration
+	N   int
+}
+
+// BackendResult holds one backend's measurements in a BackendReport.
+// If the backend failed to compile, Err is set and the rest is zero.
+type BackendResult struct {
+	Kind         Kind
+	Err          error
+	CompileTime  time.Duration
+	DatabaseSize int // compiled database bytes; Vectorscan only
+
+	// Match latency for inputs whose first match is the lowest, median and
+	// highest pattern index hit by the inputs, and for an input with no match
+	FirstHit  LatencyStats
+	MiddleHit LatencyStats
+	LastHit   LatencyStats
+	NoMatch   LatencyStats
+
+	ScanTime   time.Duration // one Match over every input
+	Throughput float64       // inputs per second during the scan
+	Matches    int           // inputs with at least one match
+}
+
+// BackendReport is the result of RunBackendComparison.
+type BackendReport struct {
+	Patterns int
+	Inputs   int
+	Backends []BackendResult // Go, then Vectorscan
+}
+
+// RunBackendComparison compiles patterns with the Go and Vectorscan backends
+// and measures compile time, per-position match latency over iterations
+// calls, and a full scan of inputs. A backend that fails to compile is
+// reported through its Err field; the returned error is only for bad
+// arguments.
+func RunBackendComparison(patterns, inputs []string, iterations int) (BackendReport, error) {
+	if len(patterns) == 0 {
+		return BackendReport{}, errors.New("no patterns provided")
+	}
+	if iterations < 1 {
+		return BackendReport{}, errors.New("iterations must be positive")
+	}
+
+	report := BackendReport{Patterns: len(patterns), Inputs: len(inputs)}
+
+	start := time.Now()
+	gm, err := gomatcher.NewGoMatcher(patterns)
+	goResult := BackendResult{Kind: KindGo, Err: err}
+	if err == nil {
+		goResult.CompileTime = time.Since(start)
+		measureBackend(&goResult, gm, inputs, iterations)
+		gm.Close()
+	}
+	report.Backends = append(report.Backends, goResult)
+
+	start = time.Now()
+	vm, err := vectorscan.NewVsMatcher(patterns)
+	vsResult := BackendResult{Kind: KindVectorscan, Err: err}
+	if err == nil {
+		vsResult.CompileTime = time.Since(start)
+		vsResult.DatabaseSize, _ = vm.DatabaseSize()
+		measureBackend(&vsResult, vm, inputs, iterations)
+		vm.Close()
+	}
+	report.Backends = append(report.Backends, vsResult)
+
+	return report, nil
+}
+
+// measureBackend fills in the latency and scan fields of r.
+func measureBackend(r *BackendResult, m gomatcher.Matcher, inputs []string, iterations int) {
+	first, middle, last, none := hitPositions(m, inputs)
+	r.FirstHit = timeMatch(m, first, iterations)
+	r.MiddleHit = timeMatch(m, middle, iterations)
+	r.LastHit = timeMatch(m, last, iterations)
+	r.NoMatch = timeMatch(m, none, iterations)
+
+	start := time.Now()
+	for _, input := range inputs {
+		if m.Match(input) >= 0 {
+			r.Matches++
+		}
+	}
+	r.ScanTime = time.Since(start)
+	if r.ScanTime > 0 {
+		r.Throughput = float64(len(inputs)) / r.ScanTime.Seconds()
+	}
+}
+
+// hitPositions picks inputs whose first match is the lowest, median and
+// highest matched pattern index, plus one input with no match. Any that
+// cannot be found are returned as "".
+func hitPositions(m gomatcher.Matcher, inputs []string) (first, middle, last, none string) {
+	type hit struct {
+		input   string
+		pattern int
+	}
+	var hits []hit
+	for _, input := range inputs {
+		id := m.Match(input)
+		if id < 0 {
+			if none == "" {
+				none = input
+			}
+			continue
+		}
+		hits = append(hits, hit{input, id})
+	}
+	if len(hits) == 0 {
+		return "", "", "", none
+	}
+
+	slices.SortStableFunc(hits, func(a, b hit) int { return a.pattern - b.pattern })
+	first = hits[0].input
+	if len(hits) > 1 {
+		last = hits[len(hits)-1].input
+	}
+	if len(hits) > 2 {
+		middle = hits[len(hits)/2].input
+	}
+	return first, middle, last, none
+}
+
+// timeMatch times n calls of m.Match(input). An empty input is not timed.
+func timeMatch(m gomatcher.Matcher, input string, n int) LatencyStats {
+	if input == "" {
+		return LatencyStats{}
+	}
+
+	times := make([]time.Duration, n)
+	for i := range times {
+		start := time.Now()
+		m.Match(input)
+		times[i] = time.Since(start)
+	}
+	slices.Sort(times)
+
+	var total time.Duration
+	for _, t := range times {
+		total += t
+	}
+	return LatencyStats{
+		Min: times[0],
+		Avg: total / time.Duration(n),
+		P95: times[min(int(float64(n)*0.95), n-1)],
+		Max: times[n-1],
+		N:   n,
+	}
+}
@@ -0,0 +1,41 @@
+package matcher
+
+import "testing"
+
+func TestRunBackendComparison(t *testing.T) {
+	patterns := []string{`virus`, `trojan`, `\.exe$`}
+	inputs := []string{"virus.txt", "trojan.dll", "setup.exe", "notes.txt"}
+
+	report, err := RunBackendComparison(patterns, inputs, 10)
+	if err != nil {
+		t.Fatalf("RunBackendComparison failed: %v", err)
+	}
+	if report.Patterns != len(patterns) || report.Inputs != len(inputs) {
+		t.Errorf("report counts = (%d, %d), want (%d, %d)", report.Patterns, report.Inputs, len(patterns), len(inputs))
+	}
+	if len(report.Backends) != 2 {
+		t.Fatalf("got %d backends, want 2", len(report.Backends))
+	}
+
+	for _, r := range report.Backends {
+		if r.Err != nil {
+			t.Errorf("%v: %v", r.Kind, r.Err)
+			continue
+		}
+		if r.CompileTime <= 0 || r.ScanTime <= 0 || r.Throughput <= 0 {
+			t.Errorf("%v: timings not populated: %+v", r.Kind, r)
+		}
+		if r.Matches != 3 {
+			t.Errorf("%v: Matches = %d, want 3", r.Kind, r.Matches)
+		}
+		for name, s := range map[string]LatencyStats{"first": r.FirstHit, "middle": r.MiddleHit, "last": r.LastHit, "none": r.NoMatch} {
+			if s.N != 10 || s.Min > s.Avg || s.Avg > s.Max {
+				t.Errorf("%v: %s-hit stats invalid: %+v", r.Kind, name, s)
+			}
+		}
+	}
+
+	if _, err := RunBackendComparison(nil, inputs, 10); err == nil {
+		t.Error("expected error for no patterns")
+	}
+}
@@ -0,0 +1,19 @@
+package matcher
+
+import (
+	gomatcher "github.com/paulstuart/cgo-ffi/matcher/go"
+	"github.com/paulstuart/cgo-ffi/matcher/vectorscan"
+)
+
+// DualMatch runs input through both backends, for shadowing the Go matcher
+// with Vectorscan before switching over. agree reports whether they returned
+// the same pattern index. When several patterns match, the Go matcher picks
+// the lowest index and Vectorscan the match that ends first, so they can
+// disagree without either being wrong. To compare only which patterns
+// match, use gomatcher.Equivalent, or compare the results of
+// PolicyMatcher.Match with LowestID on both backends.
+func DualMatch(goM *gomatcher.GoMatcher, vsM *vectorscan.VsMatcher, input string) (goResult, vsResult int, agree bool) {
+	goResult = goM.Match(input)
+	vsResult = vsM.Match(input)
+	return goResult, vsResult, goResult == vsResult
+}
@@ -0,0 +1,35 @@
+package matcher
+
+import (
+	"testing"
+
+	gomatcher "github.com/paulstuart/cgo-ffi/matcher/go"
+	"github.com/paulstuart/cgo-ffi/matcher/vectorscan"
+)
+
+func TestDualMatch(t *testing.T) {
+	patterns := []string{`\.exe$`, `evil`}
+	gm, err := gomatcher.NewGoMatcher(patterns)
+	if err != nil {
+		t.Fatalf("NewGoMatcher failed: %v", err)
+	}
+	defer gm.Close()
+	vm, err := vectorscan.NewVsMatcher(patterns)
+	if err != nil {
+		t.Fatalf("NewVsMatcher failed: %v", err)
+	}
+	defer vm.Close()
+
+	// One pattern matches, so both backends agree
+	if g, v, agree := DualMatch(gm, vm, "setup.exe"); !agree || g != 0 || v != 0 {
+		t.Errorf("DualMatch(setup.exe) = (%d, %d, %v), want (0, 0, true)", g, v, agree)
+	}
+	if g, v, agree := DualMatch(gm, vm, "notes.txt"); !agree || g != -1 || v != -1 {
+		t.Errorf("DualMatch(notes.txt) = (%d, %d, %v), want (-1, -1, true)", g, v, agree)
+	}
+
+	// Both match; Go takes pattern 0, Vectorscan the evil match that ends first
+	if g, v, agree := DualMatch(gm, vm, "evil.exe"); agree || g != 0 || v != 1 {
+		t.Errorf("DualMatch(evil.exe) = (%d, %d, %v), want (0, 1, false)", g, v, agree)
+	}
+}
@@ -0,0 +1,51 @@
+package matcher
+
+import "slices"
+
+// Corpus analysis helpers. These work over the Matcher interface, so any
+// backend can be measured the same way.
+
+// MatchRate returns the fraction of inputs matched by at least one pattern
+// of m, or 0 if inputs is empty.
+func MatchRate(m Matcher, inputs []string) float64 {
+	if len(inputs) == 0 {
+		return 0
+	}
+	matched := 0
+	for _, input := range inputs {
+		if m.Match(input) >= 0 {
+			matched++
+		}
+	}
+	return float64(matched) / float64(len(inputs))
+}
+
+// PatternHitCounts returns, for each pattern index of m, how many inputs
+// matched that pattern. Zero entries are dead rules for this corpus.
+func PatternHitCounts(m Matcher, inputs []string) []int {
+	counts := make([]int, m.PatternCount())
+	for _, input := range inputs {
+		for _, id := range m.MatchAll(input) {
+			if id >= 0 && id < len(counts) {
+				counts[id]++
+			}
+		}
+	}
+	return counts
+}
+
+// Equivalent reports whether a and b match the same set of patterns on every
+// input, as a check before swapping one backend for another. MatchAll results
+// are compared as sets: backends may report matching patterns in different
+// orders, so the first index from Match can legitimately differ.
+func Equivalent(a, b Matcher, inputs []string) bool {
+	for _, input := range inputs {
+		ids, other := a.MatchAll(input), b.MatchAll(input)
+		slices.Sort(ids)
+		slices.Sort(other)
+		if !slices.Equal(ids, other) {
+			return false
+		}
+	}
+	return true
+}
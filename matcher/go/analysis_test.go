@@ -0,0 +1,64 @@
+package matcher
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMatchRate(t *testing.T) {
+	m, err := NewGoMatcher([]string{`\.exe$`, `virus`})
+	if err != nil {
+		t.Fatalf("NewGoMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	inputs := []string{"setup.exe", "virus.txt", "notes.txt", "readme.md"}
+	if got := MatchRate(m, inputs); got != 0.5 {
+		t.Errorf("MatchRate = %v, want 0.5", got)
+	}
+	if got := MatchRate(m, nil); got != 0 {
+		t.Errorf("MatchRate(nil) = %v, want 0", got)
+	}
+}
+
+func TestPatternHitCounts(t *testing.T) {
+	m, err := NewGoMatcher([]string{`\.exe$`, `virus`, `worm`, `\.txt$`})
+	if err != nil {
+		t.Fatalf("NewGoMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	inputs := []string{"setup.exe", "virus.exe", "virus.txt", "notes.txt", "readme.md"}
+	want := []int{2, 2, 0, 2}
+	if got := PatternHitCounts(m, inputs); !slices.Equal(got, want) {
+		t.Errorf("PatternHitCounts = %v, want %v", got, want)
+	}
+}
+
+func TestEquivalent(t *testing.T) {
+	newMatcher := func(patterns ...string) *GoMatcher {
+		m, err := NewGoMatcher(patterns)
+		if err != nil {
+			t.Fatalf("NewGoMatcher failed: %v", err)
+		}
+		return m
+	}
+	a := newMatcher(`\.exe$`, `virus`)
+	defer a.Close()
+	b := newMatcher(`\.(exe)$`, `vir(us)`)
+	defer b.Close()
+	c := newMatcher(`\.exe`, `virus`)
+	defer c.Close()
+
+	inputs := []string{"setup.exe", "virus.exe", "notes.txt", "a.exe.bak"}
+	if !Equivalent(a, b, inputs) {
+		t.Error("Equivalent(a, b) = false, want true")
+	}
+	// c also matches "a.exe.bak", since its pattern is unanchored
+	if Equivalent(a, c, inputs) {
+		t.Error("Equivalent(a, c) = true, want false")
+	}
+	if !Equivalent(a, c, inputs[:3]) {
+		t.Error("Equivalent(a, c) on inputs without a.exe.bak = false, want true")
+	}
+}
@@ -0,0 +1,75 @@
+package matcher
+
+import (
+	"container/list"
+	"sync"
+)
+
+// CachedMatcher wraps a Matcher with an LRU cache of Match results keyed by
+// input, for workloads that scan the same inputs repeatedly. MatchAll is not
+// cached. It is safe for concurrent use if the wrapped Matcher is.
+type CachedMatcher struct {
+	Matcher
+
+	size int
+
+	mu      sync.Mutex
+	order   *list.List               // most recently used at the front
+	entries map[string]*list.Element // values are *cacheEntry
+}
+
+type cacheEntry struct {
+	input string
+	id    int
+}
+
+// NewCachedMatcher returns m with an LRU cache holding up to size Match
+// results. A size of 0 or less disables caching.
+func NewCachedMatcher(m Matcher, size int) *CachedMatcher {
+	return &CachedMatcher{
+		Matcher: m,
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Match returns the cached result for input if there is one, and otherwise
+// calls the wrapped Match and caches its result, evicting the least recently
+// used entry when the cache is full. The wrapped Match runs without the
+// cache lock held, so concurrent misses on the same input may both scan.
+func (c *CachedMatcher) Match(input string) int {
+	if c.size <= 0 {
+		return c.Matcher.Match(input)
+	}
+
+	c.mu.Lock()
+	if e, ok := c.entries[input]; ok {
+		c.order.MoveToFront(e)
+		id := e.Value.(*cacheEntry).id
+		c.mu.Unlock()
+		return id
+	}
+	c.mu.Unlock()
+
+	id := c.Matcher.Match(input)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[input]; !ok {
+		c.entries[input] = c.order.PushFront(&cacheEntry{input: input, id: id})
+		if c.order.Len() > c.size {
+			oldest := c.order.Back()
+			c.order.Remove(oldest)
+			delete(c.entries, oldest.Value.(*cacheEntry).input)
+		}
+	}
+	return id
+}
+
+// Len returns the number of cached results.
+func (c *CachedMatcher) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,53 @@
+package matcher
+
+import "testing"
+
+// countingMatcher counts calls to Match on the wrapped Matcher.
+type countingMatcher struct {
+	Matcher
+	calls int
+}
+
+func (c *countingMatcher) Match(input string) int {
+	c.calls++
+	return c.Matcher.Match(input)
+}
+
+func TestCachedMatcher(t *testing.T) {
+	m, err := NewGoMatcher([]string{`foo`, `bar`})
+	if err != nil {
+		t.Fatalf("NewGoMatcher failed: %v", err)
+	}
+	counter := &countingMatcher{Matcher: m}
+	cached := NewCachedMatcher(counter, 2)
+	defer cached.Close()
+
+	if got := cached.Match("bar"); got != 1 {
+		t.Fatalf("Match(bar) = %d, want 1", got)
+	}
+	if got := cached.Match("bar"); got != 1 || counter.calls != 1 {
+		t.Errorf("second Match(bar) = %d with %d scans, want 1 from cache", got, counter.calls)
+	}
+
+	// A miss is cached too; a third input then evicts the least recent, bar
+	cached.Match("none")
+	cached.Match("foo")
+	if cached.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", cached.Len())
+	}
+	calls := counter.calls
+	cached.Match("none")
+	cached.Match("bar")
+	if counter.calls != calls+1 {
+		t.Errorf("got %d scans for a hit and an evicted entry, want 1", counter.calls-calls)
+	}
+
+	// Size 0 disables the cache
+	counter.calls = 0
+	uncached := NewCachedMatcher(counter, 0)
+	uncached.Match("foo")
+	uncached.Match("foo")
+	if counter.calls != 2 || uncached.Len() != 0 {
+		t.Errorf("size 0: %d scans and %d cached, want 2 and 0", counter.calls, uncached.Len())
+	}
+}
@@ -0,0 +1,32 @@
+package matcher
+
+import "strings"
+
+// DedupPatterns drops exact-duplicate patterns, keeping the first occurrence
+// of each. It returns the remaining patterns in their original order and the
+// indices (into patterns) of the ones removed. Note that pattern indices
+// reported by a matcher built from unique refer to unique, not patterns.
+func DedupPatterns(patterns []string) (unique []string, removed []int) {
+	return dedupBy(patterns, func(p string) string { return p })
+}
+
+// DedupPatternsFold is like DedupPatterns but also treats patterns differing
+// only in letter case as duplicates. Use it for rule sets compiled
+// case-insensitively, such as VsMatcher or NewGoMatcherWithFlags with "i".
+func DedupPatternsFold(patterns []string) (unique []string, removed []int) {
+	return dedupBy(patterns, strings.ToLower)
+}
+
+func dedupBy(patterns []string, key func(string) string) (unique []string, removed []int) {
+	seen := make(map[string]bool, len(patterns))
+	for i, p := range patterns {
+		k := key(p)
+		if seen[k] {
+			removed = append(removed, i)
+			continue
+		}
+		seen[k] = true
+		unique = append(unique, p)
+	}
+	return unique, removed
+}
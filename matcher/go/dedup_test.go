@@ -0,0 +1,30 @@
+package matcher
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDedupPatterns(t *testing.T) {
+	patterns := []string{`virus`, `trojan`, `virus`, `Trojan`, `\.exe$`, `trojan`}
+
+	unique, removed := DedupPatterns(patterns)
+	if want := []string{`virus`, `trojan`, `Trojan`, `\.exe$`}; !slices.Equal(unique, want) {
+		t.Errorf("unique = %v, want %v", unique, want)
+	}
+	if want := []int{2, 5}; !slices.Equal(removed, want) {
+		t.Errorf("removed = %v, want %v", removed, want)
+	}
+
+	unique, removed = DedupPatternsFold(patterns)
+	if want := []string{`virus`, `trojan`, `\.exe$`}; !slices.Equal(unique, want) {
+		t.Errorf("fold unique = %v, want %v", unique, want)
+	}
+	if want := []int{2, 3, 5}; !slices.Equal(removed, want) {
+		t.Errorf("fold removed = %v, want %v", removed, want)
+	}
+
+	if unique, removed := DedupPatterns(nil); unique != nil || removed != nil {
+		t.Errorf("DedupPatterns(nil) = %v, %v; want nil, nil", unique, removed)
+	}
+}
@@ -0,0 +1,35 @@
+package matcher
+
+// Match is a single match of one pattern, with byte offsets into the input.
+type Match struct {
+	ID    int // pattern index
+	Start int // offset of the first matched byte
+	End   int // offset just past the last matched byte
+}
+
+// Matchers report results in three shapes: a single pattern index from Match,
+// a list of indices from MatchAll, and positioned Match values. The helpers
+// below convert between them.
+
+// FirstOf returns the first index in ids, or -1 if ids is empty, turning a
+// MatchAll result into a Match result.
+func FirstOf(ids []int) int {
+	if len(ids) == 0 {
+		return -1
+	}
+	return ids[0]
+}
+
+// IDsOf returns the pattern index of each match in order, dropping repeats,
+// so the result has the same shape as MatchAll. It returns nil for no matches.
+func IDsOf(matches []Match) []int {
+	var ids []int
+	seen := make(map[int]bool)
+	for _, m := range matches {
+		if !seen[m.ID] {
+			ids = append(ids, m.ID)
+			seen[m.ID] = true
+		}
+	}
+	return ids
+}
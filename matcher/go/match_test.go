@@ -0,0 +1,27 @@
+package matcher
+
+import "testing"
+
+func TestFirstOf(t *testing.T) {
+	if got := FirstOf(nil); got != -1 {
+		t.Errorf("FirstOf(nil) = %d, want -1", got)
+	}
+	if got := FirstOf([]int{3, 1, 2}); got != 3 {
+		t.Errorf("FirstOf([3 1 2]) = %d, want 3", got)
+	}
+}
+
+func TestIDsOf(t *testing.T) {
+	if got := IDsOf(nil); got != nil {
+		t.Errorf("IDsOf(nil) = %v, want nil", got)
+	}
+
+	matches := []Match{
+		{ID: 2, Start: 0, End: 4},
+		{ID: 0, Start: 5, End: 9},
+		{ID: 2, Start: 10, End: 14},
+	}
+	if got := IDsOf(matches); !intSliceEqual(got, []int{2, 0}) {
+		t.Errorf("IDsOf = %v, want [2 0]", got)
+	}
+}
@@ -5,8 +5,13 @@
 package matcher
 
 import (
+	"encoding/json"
 	"fmt"
 	"regexp"
+	"regexp/syntax"
+	"slices"
+	"strings"
+	"unicode"
 )
 
 // Matcher interface for multi-pattern regex matching.
@@ -44,6 +49,177 @@ func NewGoMatcher(patterns []string) (*GoMatcher, error) {
 	return &GoMatcher{patterns: compiled}, nil
 }
 
+// NewGoMatcherWithFlags is like NewGoMatcher but prepends the RE2 flags in
+// flags, e.g. "is" becomes "(?is)", to every pattern. Valid flags are i, m,
+// s and U; any other character is an error.
+func NewGoMatcherWithFlags(patterns []string, flags string) (*GoMatcher, error) {
+	if flags == "" {
+		return NewGoMatcher(patterns)
+	}
+	for _, f := range flags {
+		if !strings.ContainsRune("imsU", f) {
+			return nil, fmt.Errorf("invalid flag %q in %q", f, flags)
+		}
+	}
+
+	prefixed := make([]string, len(patterns))
+	for i, p := range patterns {
+		prefixed[i] = "(?" + flags + ")" + p
+	}
+	return NewGoMatcher(prefixed)
+}
+
+// NewGoMatcherASCIIFold creates a GoMatcher whose patterns match ASCII letters
+// case-insensitively and everything else exactly, mirroring Vectorscan's
+// Caseless flag. RE2's (?i) folds case per Unicode, so (?i)k also matches the
+// Kelvin sign U+212A and (?i)s matches ſ U+017F, while Vectorscan does not;
+// use this constructor when comparing results across the two backends.
+func NewGoMatcherASCIIFold(patterns []string) (*GoMatcher, error) {
+	folded := make([]string, len(patterns))
+	for i, p := range patterns {
+		re, err := syntax.Parse(p, syntax.Perl)
+		if err != nil {
+			return nil, fmt.Errorf("pattern %d (%q): %w", i, p, err)
+		}
+		folded[i] = asciiFold(re).String()
+	}
+	return NewGoMatcher(folded)
+}
+
+// asciiFold rewrites re so each ASCII letter matches both cases and no other
+// case folding applies.
+func asciiFold(re *syntax.Regexp) *syntax.Regexp {
+	folded := re.Flags&syntax.FoldCase != 0
+	re.Flags &^= syntax.FoldCase
+
+	switch re.Op {
+	case syntax.OpLiteral:
+		subs := make([]*syntax.Regexp, len(re.Rune))
+		for i, r := range re.Rune {
+			if lower := r | 0x20; lower >= 'a' && lower <= 'z' {
+				subs[i] = &syntax.Regexp{Op: syntax.OpCharClass, Rune: []rune{lower - 0x20, lower - 0x20, lower, lower}}
+			} else {
+				subs[i] = &syntax.Regexp{Op: syntax.OpLiteral, Rune: []rune{r}, Flags: re.Flags}
+			}
+		}
+		if len(subs) == 1 {
+			return subs[0]
+		}
+		return &syntax.Regexp{Op: syntax.OpConcat, Sub: subs}
+
+	case syntax.OpCharClass:
+		// The parser stores [^...] as its complement, which would match
+		// everything once case partners are added. Such a class reaches
+		// unicode.MaxRune, so it is complemented back, closed under ASCII
+		// case and complemented again.
+		ranges := re.Rune
+		negated := len(ranges) > 0 && ranges[len(ranges)-1] == unicode.MaxRune
+		if negated {
+			ranges = complementRanges(ranges)
+		}
+		if folded {
+			// The parser has already closed a (?i) class under Unicode
+			// folding; drop the non-ASCII runes it added for ASCII letters.
+			// Other non-ASCII case pairs cannot be told apart from runes
+			// written in the class, so they keep Unicode folding.
+			ranges = removeRunes(ranges, asciiFoldPartners)
+		}
+		ranges = asciiCaseClose(ranges)
+		if negated {
+			ranges = complementRanges(ranges)
+		}
+		re.Rune = ranges
+	}
+
+	for i, sub := range re.Sub {
+		re.Sub[i] = asciiFold(sub)
+	}
+	return re
+}
+
+// asciiFoldPartners are the non-ASCII runes that Unicode simple folding
+// pairs with an ASCII letter: U+017F ſ with s and U+212A Kelvin sign with k.
+var asciiFoldPartners = func() []rune {
+	var partners []rune
+	for r := 'A'; r <= 'z'; r++ {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			if f > unicode.MaxASCII && !slices.Contains(partners, f) {
+				partners = append(partners, f)
+			}
+		}
+	}
+	slices.Sort(partners)
+	return partners
+}()
+
+// removeRunes returns the class ranges with each rune in drop taken out.
+// drop must be sorted.
+func removeRunes(ranges []rune, drop []rune) []rune {
+	out := make([]rune, 0, len(ranges))
+	for i := 0; i < len(ranges); i += 2 {
+		lo, hi := ranges[i], ranges[i+1]
+		for _, d := range drop {
+			if d < lo || d > hi {
+				continue
+			}
+			if d > lo {
+				out = append(out, lo, d-1)
+			}
+			lo = d + 1
+		}
+		if lo <= hi {
+			out = append(out, lo, hi)
+		}
+	}
+	return out
+}
+
+// asciiCaseClose returns the class ranges with the other case of every ASCII
+// letter added, sorted and merged.
+func asciiCaseClose(ranges []rune) []rune {
+	var pairs [][2]rune
+	for i := 0; i < len(ranges); i += 2 {
+		lo, hi := ranges[i], ranges[i+1]
+		pairs = append(pairs, [2]rune{lo, hi})
+		if l, h := max(lo, 'a'), min(hi, 'z'); l <= h {
+			pairs = append(pairs, [2]rune{l - 0x20, h - 0x20})
+		}
+		if l, h := max(lo, 'A'), min(hi, 'Z'); l <= h {
+			pairs = append(pairs, [2]rune{l + 0x20, h + 0x20})
+		}
+	}
+	slices.SortFunc(pairs, func(a, b [2]rune) int { return int(a[0] - b[0]) })
+
+	out := make([]rune, 0, 2*len(pairs))
+	for _, p := range pairs {
+		if n := len(out); n > 0 && p[0] <= out[n-1]+1 {
+			out[n-1] = max(out[n-1], p[1])
+			continue
+		}
+		out = append(out, p[0], p[1])
+	}
+	return out
+}
+
+// complementRanges returns the runes not in the sorted, merged class ranges.
+func complementRanges(ranges []rune) []rune {
+	out := make([]rune, 0, len(ranges)+2)
+	next := rune(0)
+	for i := 0; i < len(ranges); i += 2 {
+		if ranges[i] > next {
+			out = append(out, next, ranges[i]-1)
+		}
+		next = ranges[i+1] + 1
+	}
+	if next <= unicode.MaxRune {
+		out = append(out, next, unicode.MaxRune)
+	}
+	return out
+}
+
 // Match returns the index of the first matching pattern, or -1 if no match.
 // Patterns are tested in order; returns on first match.
 func (m *GoMatcher) Match(input string) int {
@@ -55,6 +231,21 @@ func (m *GoMatcher) Match(input string) int {
 	return -1
 }
 
+// MatchBudget is Match limited to the first maxPatterns patterns, bounding
+// worst-case latency at the risk of missing later patterns. complete reports
+// whether the result is the one Match would return: it is false only when
+// the budget ran out before any pattern matched and some patterns were left
+// unchecked.
+func (m *GoMatcher) MatchBudget(input string, maxPatterns int) (id int, complete bool) {
+	limit := min(max(maxPatterns, 0), len(m.patterns))
+	for i, re := range m.patterns[:limit] {
+		if re.MatchString(input) {
+			return i, true
+		}
+	}
+	return -1, limit == len(m.patterns)
+}
+
 // MatchAll returns indices of all matching patterns.
 func (m *GoMatcher) MatchAll(input string) []int {
 	var matches []int
@@ -66,6 +257,29 @@ func (m *GoMatcher) MatchAll(input string) []int {
 	return matches
 }
 
+// PatternMatchInfo is one pattern's result from Explain.
+type PatternMatchInfo struct {
+	Index   int  // pattern index
+	Matched bool // whether the pattern matched the input
+	Start   int  // offset of the leftmost match, or -1
+	End     int  // offset just past the leftmost match, or -1
+}
+
+// Explain evaluates every pattern against input and reports each one's
+// result, for working out why a rule did or didn't fire. Unlike Match, it
+// does not stop at the first matching pattern.
+func (m *GoMatcher) Explain(input string) []PatternMatchInfo {
+	info := make([]PatternMatchInfo, len(m.patterns))
+	for i, re := range m.patterns {
+		info[i] = PatternMatchInfo{Index: i, Start: -1, End: -1}
+		if loc := re.FindStringIndex(input); loc != nil {
+			info[i].Matched = true
+			info[i].Start, info[i].End = loc[0], loc[1]
+		}
+	}
+	return info
+}
+
 // PatternCount returns the number of patterns.
 func (m *GoMatcher) PatternCount() int {
 	return len(m.patterns)
@@ -73,3 +287,33 @@ func (m *GoMatcher) PatternCount() int {
 
 // Close releases resources. For GoMatcher this is a no-op.
 func (m *GoMatcher) Close() {}
+
+// patternSet is the serialized form of a GoMatcher.
+type patternSet struct {
+	Patterns []string `json:"patterns"`
+}
+
+// Patterns returns the source text of each compiled pattern.
+func (m *GoMatcher) Patterns() []string {
+	sources := make([]string, len(m.patterns))
+	for i, re := range m.patterns {
+		sources[i] = re.String()
+	}
+	return sources
+}
+
+// MarshalPatterns returns the matcher's source patterns as JSON.
+// Compiled regexps are not serializable, so UnmarshalGoMatcher recompiles them.
+func (m *GoMatcher) MarshalPatterns() []byte {
+	data, _ := json.Marshal(patternSet{Patterns: m.Patterns()}) // a []string always marshals
+	return data
+}
+
+// UnmarshalGoMatcher rebuilds a GoMatcher from the output of MarshalPatterns.
+func UnmarshalGoMatcher(data []byte) (*GoMatcher, error) {
+	var set patternSet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("invalid pattern data: %w", err)
+	}
+	return NewGoMatcher(set.Patterns)
+}
@@ -2,7 +2,10 @@ package matcher
 
 import (
 	"fmt"
+	"slices"
 	"testing"
+
+	"github.com/paulstuart/cgo-ffi/matcher/testdata"
 )
 
 func TestGoMatcher_Match(t *testing.T) {
@@ -82,6 +85,142 @@ func TestGoMatcher_InvalidPattern(t *testing.T) {
 	}
 }
 
+func TestGoMatcher_MarshalPatterns(t *testing.T) {
+	patterns := []string{`^\d{3}-\d{4}$`, `error|fail|panic`, `(?i)https?://`}
+
+	m, err := NewGoMatcher(patterns)
+	if err != nil {
+		t.Fatalf("NewGoMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	restored, err := UnmarshalGoMatcher(m.MarshalPatterns())
+	if err != nil {
+		t.Fatalf("UnmarshalGoMatcher failed: %v", err)
+	}
+	defer restored.Close()
+
+	got := restored.Patterns()
+	if len(got) != len(patterns) {
+		t.Fatalf("restored %d patterns, want %d", len(got), len(patterns))
+	}
+	for i := range patterns {
+		if got[i] != patterns[i] {
+			t.Errorf("pattern %d = %q, want %q", i, got[i], patterns[i])
+		}
+	}
+
+	for _, input := range []string{"123-4567", "it failed", "HTTP://x", "nothing"} {
+		if a, b := m.Match(input), restored.Match(input); a != b {
+			t.Errorf("Match(%q): original=%d, restored=%d", input, a, b)
+		}
+	}
+
+	if _, err := UnmarshalGoMatcher([]byte("not json")); err == nil {
+		t.Error("expected error for invalid data")
+	}
+}
+
+func TestNewGoMatcherWithFlags(t *testing.T) {
+	m, err := NewGoMatcherWithFlags([]string{`virus`, `^trojan`}, "i")
+	if err != nil {
+		t.Fatalf("NewGoMatcherWithFlags failed: %v", err)
+	}
+	defer m.Close()
+
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"VIRUS.exe", 0},
+		{"Trojan.dll", 1},
+		{"clean.txt", -1},
+	}
+	for _, tt := range tests {
+		if got := m.Match(tt.input); got != tt.want {
+			t.Errorf("Match(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+
+	if _, err := NewGoMatcherWithFlags([]string{`virus`}, "ix"); err == nil {
+		t.Error("expected error for invalid flag")
+	}
+}
+
+func TestNewGoMatcherASCIIFold(t *testing.T) {
+	ascii, err := NewGoMatcherASCIIFold([]string{`kernel`, `[a-c]x`, `é`})
+	if err != nil {
+		t.Fatalf("NewGoMatcherASCIIFold failed: %v", err)
+	}
+	defer ascii.Close()
+
+	unicode, err := NewGoMatcherWithFlags([]string{`kernel`, `[a-c]x`, `é`}, "i")
+	if err != nil {
+		t.Fatalf("NewGoMatcherWithFlags failed: %v", err)
+	}
+	defer unicode.Close()
+
+	tests := []struct {
+		input       string
+		wantASCII   int
+		wantUnicode int
+	}{
+		{"KERNEL32.dll", 0, 0},
+		{"BX", 1, 1},
+		{"Kernel", -1, 0}, // Kelvin sign folds to k only under Unicode rules
+		{"É", -1, 2},      // non-ASCII letters stay case-sensitive
+		{"é", 2, 2},
+	}
+
+	for _, tt := range tests {
+		if got := ascii.Match(tt.input); got != tt.wantASCII {
+			t.Errorf("ASCII fold Match(%q) = %d, want %d", tt.input, got, tt.wantASCII)
+		}
+		if got := unicode.Match(tt.input); got != tt.wantUnicode {
+			t.Errorf("Unicode fold Match(%q) = %d, want %d", tt.input, got, tt.wantUnicode)
+		}
+	}
+
+	// (?i) classes arrive from the parser already closed under Unicode
+	// folding, so the Kelvin sign and long s must be taken back out.
+	// Negated classes arrive complemented and must stay negated.
+	classes := []string{`^(?i)[a-z]+$`, `^(?i)[k]$`, `^(?i)\w$`, `^[^a-z]+$`, `^(?i)[^k]$`}
+	asciiClass, err := NewGoMatcherASCIIFold(classes)
+	if err != nil {
+		t.Fatalf("NewGoMatcherASCIIFold failed: %v", err)
+	}
+	defer asciiClass.Close()
+
+	unicodeClass, err := NewGoMatcherWithFlags(classes, "")
+	if err != nil {
+		t.Fatalf("NewGoMatcherWithFlags failed: %v", err)
+	}
+	defer unicodeClass.Close()
+
+	classTests := []struct {
+		input       string
+		wantASCII   []int
+		wantUnicode []int
+	}{
+		{"Abc", []int{0}, []int{0}},
+		{"ABC", []int{0}, []int{0, 3}},
+		{"K", []int{0, 1, 2}, []int{0, 1, 2, 3}},
+		{"\u212a", []int{3, 4}, []int{0, 1, 2, 3}}, // Kelvin sign
+		{"\u017f", []int{3, 4}, []int{0, 2, 3, 4}}, // long s
+		{"_", []int{2, 3, 4}, []int{2, 3, 4}},
+		{"123", []int{3}, []int{3}},
+	}
+
+	for _, tt := range classTests {
+		if got := asciiClass.MatchAll(tt.input); !intSliceEqual(got, tt.wantASCII) {
+			t.Errorf("ASCII fold MatchAll(%q) = %v, want %v", tt.input, got, tt.wantASCII)
+		}
+		if got := unicodeClass.MatchAll(tt.input); !intSliceEqual(got, tt.wantUnicode) {
+			t.Errorf("Unicode fold MatchAll(%q) = %v, want %v", tt.input, got, tt.wantUnicode)
+		}
+	}
+}
+
 func intSliceEqual(a, b []int) bool {
 	if len(a) != len(b) {
 		return false
@@ -197,3 +336,71 @@ func generateInput(length, patternIdx int) string {
 	}
 	return string(padding) + " " + pattern
 }
+
+// Benchmarks for pattern compilation with varying pattern counts
+func BenchmarkGoMatcher_Compile_10(b *testing.B)  { benchmarkCompile(b, 10) }
+func BenchmarkGoMatcher_Compile_100(b *testing.B) { benchmarkCompile(b, 100) }
+func BenchmarkGoMatcher_Compile_256(b *testing.B) { benchmarkCompile(b, 256) }
+
+func benchmarkCompile(b *testing.B, patternCount int) {
+	if patternCount > len(testdata.MalwarePatterns) {
+		patternCount = len(testdata.MalwarePatterns)
+	}
+	patterns := testdata.MalwarePatterns[:patternCount]
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m, err := NewGoMatcher(patterns)
+		if err != nil {
+			b.Fatalf("NewGoMatcher failed: %v", err)
+		}
+		m.Close()
+	}
+}
+
+func TestGoMatcher_Explain(t *testing.T) {
+	m, err := NewGoMatcher([]string{`worm`, `\.exe$`, `rootkit`, `trojan`})
+	if err != nil {
+		t.Fatalf("NewGoMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	got := m.Explain("trojan.exe")
+	want := []PatternMatchInfo{
+		{Index: 0, Matched: false, Start: -1, End: -1},
+		{Index: 1, Matched: true, Start: 6, End: 10},
+		{Index: 2, Matched: false, Start: -1, End: -1},
+		{Index: 3, Matched: true, Start: 0, End: 6},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Explain = %+v, want %+v", got, want)
+	}
+}
+
+func TestGoMatcher_MatchBudget(t *testing.T) {
+	m, err := NewGoMatcher([]string{`alpha`, `beta`, `gamma`, `delta`})
+	if err != nil {
+		t.Fatalf("NewGoMatcher failed: %v", err)
+	}
+
+	tests := []struct {
+		input        string
+		budget       int
+		want         int
+		wantComplete bool
+	}{
+		{"delta", 2, -1, false}, // stops before reaching delta
+		{"delta", 4, 3, true},
+		{"beta", 2, 1, true}, // a match within budget is what Match returns
+		{"none", 4, -1, true},
+		{"none", 10, -1, true},
+		{"alpha", 0, -1, false},
+	}
+	for _, tt := range tests {
+		got, complete := m.MatchBudget(tt.input, tt.budget)
+		if got != tt.want || complete != tt.wantComplete {
+			t.Errorf("MatchBudget(%q, %d) = (%d, %v), want (%d, %v)",
+				tt.input, tt.budget, got, complete, tt.want, tt.wantComplete)
+		}
+	}
+}
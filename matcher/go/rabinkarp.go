@@ -0,0 +1,138 @@
+package matcher
+
+import "slices"
+
+// rkBase is the multiplier for the rolling hash. Arithmetic wraps mod 2^64;
+// collisions only cost a string compare, since every candidate is verified.
+const rkBase = 16777619
+
+// RabinKarpMatcher matches literal strings with a multi-pattern Rabin-Karp
+// search: one rolling hash per distinct literal length, looked up in a hash
+// table of literal hashes and confirmed with a direct compare. It is a pure
+// Go baseline for long literal rule sets, where it avoids running one regexp
+// per pattern. Matching is case-sensitive.
+type RabinKarpMatcher struct {
+	literals []string
+	groups   []rkGroup
+	empty    []int // indices of empty literals, which match every input
+}
+
+// rkFilterBits sizes the per-group bitset that screens window hashes before
+// the map lookup; most windows of a non-matching input miss it.
+const rkFilterBits = 1 << 16
+
+// rkGroup holds the literals of one length.
+type rkGroup struct {
+	length int
+	pow    uint64           // rkBase^(length-1), to drop the leading byte
+	ids    map[uint64][]int // literal hash -> indices, ascending
+	filter []uint64         // bit h%rkFilterBits set for each literal hash h
+}
+
+// NewRabinKarpMatcher creates a matcher for the given literal strings.
+func NewRabinKarpMatcher(literals []string) *RabinKarpMatcher {
+	m := &RabinKarpMatcher{literals: literals}
+	byLen := make(map[int]*rkGroup)
+	for i, lit := range literals {
+		if lit == "" {
+			m.empty = append(m.empty, i)
+			continue
+		}
+		g := byLen[len(lit)]
+		if g == nil {
+			g = &rkGroup{
+				length: len(lit),
+				pow:    1,
+				ids:    make(map[uint64][]int),
+				filter: make([]uint64, rkFilterBits/64),
+			}
+			for range len(lit) - 1 {
+				g.pow *= rkBase
+			}
+			byLen[len(lit)] = g
+		}
+		h := rkHash(lit)
+		g.ids[h] = append(g.ids[h], i)
+		g.filter[h%rkFilterBits/64] |= 1 << (h % 64)
+	}
+	for _, g := range byLen {
+		m.groups = append(m.groups, *g)
+	}
+	slices.SortFunc(m.groups, func(a, b rkGroup) int { return a.length - b.length })
+	return m
+}
+
+// rkHash hashes s the same way the rolling hash does.
+func rkHash(s string) uint64 {
+	var h uint64
+	for i := 0; i < len(s); i++ {
+		h = h*rkBase + uint64(s[i])
+	}
+	return h
+}
+
+// scan calls fn with the index of each literal found in input, possibly more
+// than once per literal. fn returns false to stop scanning.
+func (m *RabinKarpMatcher) scan(input string, fn func(id int) bool) {
+	for _, id := range m.empty {
+		if !fn(id) {
+			return
+		}
+	}
+	for _, g := range m.groups {
+		if g.length > len(input) {
+			break
+		}
+		h := rkHash(input[:g.length])
+		for i := 0; ; i++ {
+			if g.filter[h%rkFilterBits/64]&(1<<(h%64)) != 0 {
+				for _, id := range g.ids[h] {
+					if input[i:i+g.length] == m.literals[id] && !fn(id) {
+						return
+					}
+				}
+			}
+			if i+g.length == len(input) {
+				break
+			}
+			h = (h-uint64(input[i])*g.pow)*rkBase + uint64(input[i+g.length])
+		}
+	}
+}
+
+// Match returns the lowest index of a literal contained in input, or -1.
+func (m *RabinKarpMatcher) Match(input string) int {
+	best := -1
+	m.scan(input, func(id int) bool {
+		if best < 0 || id < best {
+			best = id
+		}
+		return best != 0
+	})
+	return best
+}
+
+// MatchAll returns the indices of all literals contained in input, ascending.
+func (m *RabinKarpMatcher) MatchAll(input string) []int {
+	found := make([]bool, len(m.literals))
+	m.scan(input, func(id int) bool {
+		found[id] = true
+		return true
+	})
+
+	var ids []int
+	for id, ok := range found {
+		if ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// PatternCount returns the number of literals.
+func (m *RabinKarpMatcher) PatternCount() int {
+	return len(m.literals)
+}
+
+// Close releases resources. For RabinKarpMatcher this is a no-op.
+func (m *RabinKarpMatcher) Close() {}
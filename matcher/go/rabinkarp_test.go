@@ -0,0 +1,95 @@
+package matcher
+
+import (
+	"math/rand"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRabinKarpMatcher(t *testing.T) {
+	literals := []string{"trojan.exe", "virus", "rus", "ransomware-payload", "virus", "a.b"}
+	m := NewRabinKarpMatcher(literals)
+	defer m.Close()
+
+	tests := []struct {
+		input string
+		match int
+		all   []int
+	}{
+		{"/tmp/trojan.exe", 0, []int{0}},
+		{"virus.txt", 1, []int{1, 2, 4}},
+		{"walrus", 2, []int{2}},
+		{"drop ransomware-payload here", 3, []int{3}},
+		{"a.b", 5, []int{5}},
+		{"axb", -1, nil},
+		{"", -1, nil},
+	}
+	for _, tt := range tests {
+		if got := m.Match(tt.input); got != tt.match {
+			t.Errorf("Match(%q) = %d, want %d", tt.input, got, tt.match)
+		}
+		if got := m.MatchAll(tt.input); !slices.Equal(got, tt.all) {
+			t.Errorf("MatchAll(%q) = %v, want %v", tt.input, got, tt.all)
+		}
+	}
+	if got := m.PatternCount(); got != len(literals) {
+		t.Errorf("PatternCount = %d, want %d", got, len(literals))
+	}
+}
+
+func TestRabinKarpMatcher_AgreesWithGoMatcher(t *testing.T) {
+	literals := []string{"ab", "abc", "bca", "cab", "aaaa", "c"}
+	quoted := make([]string, len(literals))
+	for i, lit := range literals {
+		quoted[i] = regexp.QuoteMeta(lit)
+	}
+	gm, err := NewGoMatcher(quoted)
+	if err != nil {
+		t.Fatalf("NewGoMatcher failed: %v", err)
+	}
+	rk := NewRabinKarpMatcher(literals)
+
+	rng := rand.New(rand.NewSource(1))
+	for range 1000 {
+		b := make([]byte, rng.Intn(12))
+		for i := range b {
+			b[i] = "abc"[rng.Intn(3)]
+		}
+		input := string(b)
+		if got, want := rk.Match(input), gm.Match(input); got != want {
+			t.Fatalf("Match(%q) = %d, GoMatcher = %d", input, got, want)
+		}
+		if got, want := rk.MatchAll(input), gm.MatchAll(input); !slices.Equal(got, want) {
+			t.Fatalf("MatchAll(%q) = %v, GoMatcher = %v", input, got, want)
+		}
+	}
+}
+
+// Rabin-Karp vs regexp on literal patterns and a long input with no match, so
+// every pattern is checked. Regexp cost grows with the pattern count;
+// Rabin-Karp cost grows with the number of distinct literal lengths.
+func BenchmarkRabinKarpMatcher_Match_100(b *testing.B)   { benchmarkLiteralMatch(b, 100, true) }
+func BenchmarkRabinKarpMatcher_Match_1000(b *testing.B)  { benchmarkLiteralMatch(b, 1000, true) }
+func BenchmarkGoMatcher_Match_Literal_100(b *testing.B)  { benchmarkLiteralMatch(b, 100, false) }
+func BenchmarkGoMatcher_Match_Literal_1000(b *testing.B) { benchmarkLiteralMatch(b, 1000, false) }
+
+func benchmarkLiteralMatch(b *testing.B, patternCount int, rabinKarp bool) {
+	patterns := generatePatterns(patternCount)
+	var m Matcher = NewRabinKarpMatcher(patterns)
+	if !rabinKarp {
+		gm, err := NewGoMatcher(patterns)
+		if err != nil {
+			b.Fatalf("NewGoMatcher failed: %v", err)
+		}
+		m = gm
+	}
+	defer m.Close()
+	input := strings.Repeat("pattern-", 625)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Match(input)
+	}
+}
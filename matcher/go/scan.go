@@ -0,0 +1,62 @@
+package matcher
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ScanLines matches each line of r against m and writes the matching lines to
+// w as "line:pattern:text", with 1-based line numbers. Trailing "\r\n" or
+// "\n" is stripped before matching.
+func ScanLines(m Matcher, r io.Reader, w io.Writer) error {
+	br := bufio.NewReader(r)
+	for line := 1; ; line++ {
+		raw, err := br.ReadBytes('\n')
+		if len(raw) > 0 {
+			text := string(bytes.TrimRight(raw, "\r\n"))
+			if id := m.Match(text); id >= 0 {
+				if _, werr := fmt.Fprintf(w, "%d:%d:%s\n", line, id, text); werr != nil {
+					return werr
+				}
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// gzipMagic is the two-byte header that starts every gzip stream.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// ScanCompressed runs ScanLines over the file at path, decompressing it first
+// if it is gzipped. Gzip is detected by a ".gz" extension or by the gzip
+// magic bytes, so compressed logs scan the same as plain ones.
+func ScanCompressed(m Matcher, path string, w io.Writer) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	br := bufio.NewReader(f)
+	magic, _ := br.Peek(len(gzipMagic))
+	if !strings.HasSuffix(path, ".gz") && !bytes.Equal(magic, gzipMagic) {
+		return ScanLines(m, br, w)
+	}
+
+	zr, err := gzip.NewReader(br)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	defer zr.Close()
+	return ScanLines(m, zr, w)
+}
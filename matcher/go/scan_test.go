@@ -0,0 +1,64 @@
+package matcher
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestScanLines(t *testing.T) {
+	m, err := NewGoMatcher([]string{`error`, `panic`})
+	if err != nil {
+		t.Fatalf("NewGoMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	var out bytes.Buffer
+	input := "ok\r\nan error here\nfine\npanic: boom"
+	if err := ScanLines(m, strings.NewReader(input), &out); err != nil {
+		t.Fatalf("ScanLines failed: %v", err)
+	}
+	if want := "2:0:an error here\n4:1:panic: boom\n"; out.String() != want {
+		t.Errorf("ScanLines output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestScanCompressed(t *testing.T) {
+	m, err := NewGoMatcher([]string{`error`})
+	if err != nil {
+		t.Fatalf("NewGoMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	zw.Write([]byte("start\ndisk error on sda\nend\n"))
+	zw.Close()
+
+	dir := t.TempDir()
+	want := "2:0:disk error on sda\n"
+
+	// Detected by extension, by magic bytes, and plain text passes through
+	files := map[string][]byte{
+		"app.log.gz": gz.Bytes(),
+		"app.log.1":  gz.Bytes(),
+		"app.log":    []byte("start\ndisk error on sda\nend\n"),
+	}
+	for name, data := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		var out bytes.Buffer
+		if err := ScanCompressed(m, path, &out); err != nil {
+			t.Fatalf("ScanCompressed(%s) failed: %v", name, err)
+		}
+		if out.String() != want {
+			t.Errorf("ScanCompressed(%s) = %q, want %q", name, out.String(), want)
+		}
+	}
+}
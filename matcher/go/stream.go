@@ -0,0 +1,46 @@
+package matcher
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"iter"
+)
+
+// LineMatch describes a line of a stream that matched a pattern.
+type LineMatch struct {
+	Line    int    // 1-based line number
+	Offset  int64  // byte offset of the start of the line in the stream
+	Pattern int    // index of the first matching pattern
+	Text    string // line contents without the trailing newline
+	Err     error  // set only on the final element if reading failed
+}
+
+// MatchStream returns an iterator over the lines of r that match any pattern.
+// Lines are read lazily, so breaking out of the loop stops reading r.
+// If reading fails, the last element yielded has Err set and Pattern -1.
+func (m *GoMatcher) MatchStream(r io.Reader) iter.Seq[LineMatch] {
+	return func(yield func(LineMatch) bool) {
+		br := bufio.NewReader(r)
+		var offset int64
+		for line := 1; ; line++ {
+			raw, err := br.ReadBytes('\n')
+			if len(raw) > 0 {
+				text := string(bytes.TrimRight(raw, "\r\n"))
+				if id := m.Match(text); id >= 0 {
+					if !yield(LineMatch{Line: line, Offset: offset, Pattern: id, Text: text}) {
+						return
+					}
+				}
+				offset += int64(len(raw))
+			}
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				yield(LineMatch{Line: line, Offset: offset, Pattern: -1, Err: err})
+				return
+			}
+		}
+	}
+}
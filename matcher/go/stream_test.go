@@ -0,0 +1,71 @@
+package matcher
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestGoMatcher_MatchStream(t *testing.T) {
+	m, err := NewGoMatcher([]string{`error`, `panic`})
+	if err != nil {
+		t.Fatalf("NewGoMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	input := "all good\nerror one\r\nfine\npanic two\nerror three\n"
+
+	var got []LineMatch
+	for lm := range m.MatchStream(strings.NewReader(input)) {
+		got = append(got, lm)
+	}
+	want := []LineMatch{
+		{Line: 2, Offset: 9, Pattern: 0, Text: "error one"},
+		{Line: 4, Offset: 25, Pattern: 1, Text: "panic two"},
+		{Line: 5, Offset: 35, Pattern: 0, Text: "error three"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("MatchStream yielded %d matches, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("match %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	// Breaking early stops iteration after the first match
+	var first []LineMatch
+	for lm := range m.MatchStream(strings.NewReader(input)) {
+		first = append(first, lm)
+		break
+	}
+	if len(first) != 1 || first[0].Line != 2 {
+		t.Errorf("early break yielded %+v, want only line 2", first)
+	}
+}
+
+func TestGoMatcher_MatchStreamError(t *testing.T) {
+	m, err := NewGoMatcher([]string{`error`})
+	if err != nil {
+		t.Fatalf("NewGoMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	boom := errors.New("boom")
+	r := io.MultiReader(strings.NewReader("error\n"), &failingReader{err: boom})
+
+	var last LineMatch
+	n := 0
+	for lm := range m.MatchStream(r) {
+		last = lm
+		n++
+	}
+	if n != 2 || !errors.Is(last.Err, boom) || last.Pattern != -1 {
+		t.Errorf("got %d elements, last=%+v, want 2 with final Err=%v", n, last, boom)
+	}
+}
+
+type failingReader struct{ err error }
+
+func (r *failingReader) Read([]byte) (int, error) { return 0, r.err }
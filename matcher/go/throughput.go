@@ -0,0 +1,57 @@
+package matcher
+
+import "sync/atomic"
+
+// Throughput wraps a Matcher and counts the bytes scanned, inputs seen and
+// matches found across all calls. The counters are updated atomically, so
+// Stats can be read while other goroutines are scanning.
+type Throughput struct {
+	Matcher
+
+	bytes   atomic.Int64
+	inputs  atomic.Int64
+	matches atomic.Int64
+}
+
+// NewThroughput returns a Throughput meter around m.
+func NewThroughput(m Matcher) *Throughput {
+	return &Throughput{Matcher: m}
+}
+
+// Match calls the wrapped Match and counts one match if any pattern matched.
+func (t *Throughput) Match(input string) int {
+	id := t.Matcher.Match(input)
+	matches := 0
+	if id >= 0 {
+		matches = 1
+	}
+	t.record(len(input), matches)
+	return id
+}
+
+// MatchAll calls the wrapped MatchAll and counts each matching pattern.
+func (t *Throughput) MatchAll(input string) []int {
+	ids := t.Matcher.MatchAll(input)
+	t.record(len(input), len(ids))
+	return ids
+}
+
+func (t *Throughput) record(n, matches int) {
+	t.bytes.Add(int64(n))
+	t.inputs.Add(1)
+	t.matches.Add(int64(matches))
+}
+
+// Stats returns the counters accumulated since the meter was created or
+// last reset.
+func (t *Throughput) Stats() (bytesScanned, inputs, matches int64) {
+	return t.bytes.Load(), t.inputs.Load(), t.matches.Load()
+}
+
+// ResetStats zeroes the counters and returns their values just before the
+// reset, for periodic rollups. Each counter is swapped atomically, so no
+// count is lost, but a call running concurrently may have some of its counts
+// land in the interval before the reset and the rest after it.
+func (t *Throughput) ResetStats() (bytesScanned, inputs, matches int64) {
+	return t.bytes.Swap(0), t.inputs.Swap(0), t.matches.Swap(0)
+}
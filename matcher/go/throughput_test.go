@@ -0,0 +1,47 @@
+package matcher
+
+import "testing"
+
+func TestThroughput(t *testing.T) {
+	m, err := NewGoMatcher([]string{`foo`, `bar`})
+	if err != nil {
+		t.Fatalf("NewGoMatcher failed: %v", err)
+	}
+	meter := NewThroughput(m)
+	defer meter.Close()
+
+	meter.Match("foo")        // 3 bytes, 1 match
+	meter.Match("nothing")    // 7 bytes, 0 matches
+	meter.MatchAll("foo bar") // 7 bytes, 2 matches
+	meter.MatchAll("xyz")     // 3 bytes, 0 matches
+
+	bytes, inputs, matches := meter.Stats()
+	if bytes != 20 || inputs != 4 || matches != 3 {
+		t.Errorf("Stats() = (%d, %d, %d), want (20, 4, 3)", bytes, inputs, matches)
+	}
+}
+
+func TestThroughput_ResetStats(t *testing.T) {
+	m, err := NewGoMatcher([]string{`foo`})
+	if err != nil {
+		t.Fatalf("NewGoMatcher failed: %v", err)
+	}
+	meter := NewThroughput(m)
+	defer meter.Close()
+
+	meter.Match("foo")
+	meter.Match("food")
+
+	bytes, inputs, matches := meter.ResetStats()
+	if bytes != 7 || inputs != 2 || matches != 2 {
+		t.Errorf("ResetStats() = (%d, %d, %d), want (7, 2, 2)", bytes, inputs, matches)
+	}
+	if bytes, inputs, matches := meter.Stats(); bytes != 0 || inputs != 0 || matches != 0 {
+		t.Errorf("Stats() after reset = (%d, %d, %d), want zeros", bytes, inputs, matches)
+	}
+
+	meter.Match("xyz")
+	if bytes, inputs, matches := meter.Stats(); bytes != 3 || inputs != 1 || matches != 0 {
+		t.Errorf("Stats() after reset and one call = (%d, %d, %d), want (3, 1, 0)", bytes, inputs, matches)
+	}
+}
@@ -0,0 +1,100 @@
+package matcher
+
+import (
+	"fmt"
+
+	gomatcher "github.com/paulstuart/cgo-ffi/matcher/go"
+	"github.com/paulstuart/cgo-ffi/matcher/vectorscan"
+)
+
+// MatchPolicy decides which pattern wins when several match the same input.
+// Backends disagree by default: Vectorscan reports the pattern whose match
+// ends first in the input, the Go backend the lowest pattern index.
+type MatchPolicy int
+
+const (
+	// FirstScanned keeps the backend's own Match result.
+	FirstScanned MatchPolicy = iota
+	// LowestID picks the matching pattern with the lowest index.
+	LowestID
+	// HighestPriority picks the matching pattern with the highest priority,
+	// breaking ties by lowest index.
+	HighestPriority
+)
+
+func (p MatchPolicy) String() string {
+	switch p {
+	case FirstScanned:
+		return "first-scanned"
+	case LowestID:
+		return "lowest-id"
+	case HighestPriority:
+		return "highest-priority"
+	}
+	return fmt.Sprintf("MatchPolicy(%d)", int(p))
+}
+
+// PolicyMatcher is a backend matcher whose Match result follows a
+// MatchPolicy, so every backend picks the same winner for a given input.
+type PolicyMatcher struct {
+	gomatcher.Matcher
+	policy     MatchPolicy
+	priorities []int
+}
+
+// NewPolicyMatcher builds a kind matcher for patterns whose Match applies
+// policy. priorities is parallel to patterns and is required for
+// HighestPriority; other policies ignore it. KindWasm is not supported.
+func NewPolicyMatcher(kind Kind, patterns []string, policy MatchPolicy, priorities []int) (*PolicyMatcher, error) {
+	if policy == HighestPriority && len(priorities) != len(patterns) {
+		return nil, fmt.Errorf("got %d priorities for %d patterns", len(priorities), len(patterns))
+	}
+	if policy < FirstScanned || policy > HighestPriority {
+		return nil, fmt.Errorf("unknown match policy %v", policy)
+	}
+
+	var m gomatcher.Matcher
+	var err error
+	switch kind {
+	case KindGo:
+		m, err = gomatcher.NewGoMatcher(patterns)
+	case KindVectorscan:
+		m, err = vectorscan.NewVsMatcher(patterns)
+	default:
+		return nil, fmt.Errorf("match policies are not supported for %v matchers", kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &PolicyMatcher{Matcher: m, policy: policy, priorities: priorities}, nil
+}
+
+// Match returns the pattern chosen by the policy among all patterns that
+// match input, or -1 if none does. Policies other than FirstScanned scan for
+// every match, so they cost as much as MatchAll.
+func (m *PolicyMatcher) Match(input string) int {
+	if m.policy == FirstScanned {
+		return m.Matcher.Match(input)
+	}
+
+	best := -1
+	for _, id := range m.MatchAll(input) {
+		if best < 0 || m.better(id, best) {
+			best = id
+		}
+	}
+	return best
+}
+
+// better reports whether pattern a beats pattern b under the policy.
+func (m *PolicyMatcher) better(a, b int) bool {
+	if m.policy == HighestPriority && m.priorities[a] != m.priorities[b] {
+		return m.priorities[a] > m.priorities[b]
+	}
+	return a < b
+}
+
+// Policy returns the policy m was built with.
+func (m *PolicyMatcher) Policy() MatchPolicy {
+	return m.policy
+}
@@ -0,0 +1,50 @@
+package matcher
+
+import "testing"
+
+func TestPolicyMatcher_LowestID(t *testing.T) {
+	// In "evil.exe" the \.exe$ match (pattern 0) ends after the evil match
+	// (pattern 1), so scan order and pattern order disagree
+	patterns := []string{`\.exe$`, `evil`, `trojan`}
+	inputs := []string{"evil.exe", "trojan_evil", "notes.txt", "setup.exe"}
+
+	gm, err := NewPolicyMatcher(KindGo, patterns, LowestID, nil)
+	if err != nil {
+		t.Fatalf("NewPolicyMatcher(go) failed: %v", err)
+	}
+	defer gm.Close()
+	vm, err := NewPolicyMatcher(KindVectorscan, patterns, LowestID, nil)
+	if err != nil {
+		t.Fatalf("NewPolicyMatcher(vectorscan) failed: %v", err)
+	}
+	defer vm.Close()
+
+	want := []int{0, 1, -1, 0}
+	for i, input := range inputs {
+		g, v := gm.Match(input), vm.Match(input)
+		if g != want[i] || v != want[i] {
+			t.Errorf("Match(%q): go = %d, vectorscan = %d, want %d", input, g, v, want[i])
+		}
+	}
+}
+
+func TestPolicyMatcher_HighestPriority(t *testing.T) {
+	patterns := []string{`\.exe$`, `evil`, `trojan`}
+	m, err := NewPolicyMatcher(KindGo, patterns, HighestPriority, []int{1, 5, 5})
+	if err != nil {
+		t.Fatalf("NewPolicyMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	if got := m.Match("evil.exe"); got != 1 {
+		t.Errorf("Match(evil.exe) = %d, want 1", got)
+	}
+	// Equal priorities fall back to the lowest index
+	if got := m.Match("trojan_evil.exe"); got != 1 {
+		t.Errorf("Match(trojan_evil.exe) = %d, want 1", got)
+	}
+
+	if _, err := NewPolicyMatcher(KindGo, patterns, HighestPriority, []int{1}); err == nil {
+		t.Error("HighestPriority without a priority per pattern succeeded, want error")
+	}
+}
@@ -0,0 +1,78 @@
+// Package matcher provides helpers that work across the Go, Vectorscan and
+// WASM matcher backends.
+package matcher
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+
+	"github.com/paulstuart/cgo-ffi/matcher/vectorscan"
+)
+
+// Kind identifies a matcher backend.
+type Kind int
+
+const (
+	KindGo Kind = iota
+	KindVectorscan
+	KindWasm
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindGo:
+		return "go"
+	case KindVectorscan:
+		return "vectorscan"
+	case KindWasm:
+		return "wasm"
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// ValidatePatterns checks each pattern against the rules of the given backend
+// without building a matcher. The returned slice is parallel to patterns,
+// with nil for every pattern that is valid.
+//
+//   - KindGo compiles the pattern with regexp.Compile.
+//   - KindVectorscan trial-compiles the pattern with Vectorscan.
+//   - KindWasm accepts only literals, optionally case-insensitive via (?i).
+func ValidatePatterns(patterns []string, kind Kind) []error {
+	var check func(string) error
+	switch kind {
+	case KindGo:
+		check = func(p string) error {
+			_, err := regexp.Compile(p)
+			return err
+		}
+	case KindVectorscan:
+		check = vectorscan.ValidatePattern
+	case KindWasm:
+		check = validateLiteral
+	default:
+		check = func(string) error {
+			return fmt.Errorf("unknown matcher kind %v", kind)
+		}
+	}
+
+	errs := make([]error, len(patterns))
+	for i, p := range patterns {
+		if err := check(p); err != nil {
+			errs[i] = fmt.Errorf("pattern %d (%q): %w", i, p, err)
+		}
+	}
+	return errs
+}
+
+// validateLiteral accepts patterns that parse to a single literal string.
+func validateLiteral(p string) error {
+	re, err := syntax.Parse(p, syntax.Perl)
+	if err != nil {
+		return err
+	}
+	if re.Op != syntax.OpLiteral {
+		return fmt.Errorf("not a literal pattern")
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package matcher
+
+import "testing"
+
+func TestValidatePatterns(t *testing.T) {
+	tests := []struct {
+		kind     Kind
+		patterns []string
+	}{
+		{KindGo, []string{`error|fail`, `[invalid`}},
+		{KindVectorscan, []string{`error|fail`, `(a)\1`}}, // backreferences are unsupported
+		{KindWasm, []string{`(?i)mimikatz`, `mimi.*katz`}},
+	}
+
+	for _, tt := range tests {
+		errs := ValidatePatterns(tt.patterns, tt.kind)
+		if len(errs) != len(tt.patterns) {
+			t.Fatalf("%v: got %d errors, want %d", tt.kind, len(errs), len(tt.patterns))
+		}
+		if errs[0] != nil {
+			t.Errorf("%v: pattern %q rejected: %v", tt.kind, tt.patterns[0], errs[0])
+		}
+		if errs[1] == nil {
+			t.Errorf("%v: pattern %q accepted, want error", tt.kind, tt.patterns[1])
+		}
+	}
+}
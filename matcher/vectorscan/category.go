@@ -0,0 +1,56 @@
+package vectorscan
+
+import (
+	"fmt"
+	"sort"
+)
+
+// CategorizedMatcher is a VsMatcher whose patterns are grouped into named
+// categories, so matches can be reported as a category label instead of a
+// raw pattern index.
+type CategorizedMatcher struct {
+	*VsMatcher
+	categories []string // category name for each pattern index
+}
+
+// NewCategorizedMatcher compiles all patterns from groups into one database.
+// Categories are laid out in sorted name order, so pattern indices are stable
+// for a given set of groups.
+func NewCategorizedMatcher(groups map[string][]string) (*CategorizedMatcher, error) {
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var patterns, categories []string
+	for _, name := range names {
+		for _, p := range groups[name] {
+			patterns = append(patterns, p)
+			categories = append(categories, name)
+		}
+	}
+
+	m, err := NewVsMatcher(patterns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile categories: %w", err)
+	}
+	return &CategorizedMatcher{VsMatcher: m, categories: categories}, nil
+}
+
+// MatchCategory returns the category of the first matching pattern.
+func (m *CategorizedMatcher) MatchCategory(input string) (category string, ok bool) {
+	id := m.Match(input)
+	if id < 0 {
+		return "", false
+	}
+	return m.categories[id], true
+}
+
+// Category returns the category name for a pattern index.
+func (m *CategorizedMatcher) Category(id int) string {
+	if id < 0 || id >= len(m.categories) {
+		return ""
+	}
+	return m.categories[id]
+}
@@ -0,0 +1,31 @@
+package vectorscan
+
+import "testing"
+
+func TestCategorizedMatcher(t *testing.T) {
+	m, err := NewCategorizedMatcher(map[string][]string{
+		"ransomware": {`wannacry`, `lockbit`},
+		"banking":    {`emotet`, `trickbot`},
+	})
+	if err != nil {
+		t.Fatalf("NewCategorizedMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	tests := []struct {
+		input  string
+		want   string
+		wantOK bool
+	}{
+		{"/tmp/lockbit_3.bin", "ransomware", true},
+		{"/tmp/emotet_loader.dll", "banking", true},
+		{"/usr/bin/ls", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := m.MatchCategory(tt.input)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("MatchCategory(%q) = (%q, %v), want (%q, %v)", tt.input, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package vectorscan
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	hs "github.com/flier/gohs/hyperscan"
+)
+
+// Export container layout, all integers little-endian:
+//
+//	magic    [4]byte "VSDB"
+//	version  uint8
+//	flags    uint32   compile flags applied to every pattern
+//	count    uint32   number of patterns
+//	patterns count × (uint32 length, bytes)
+//	database uint32 length, bytes from hs.BlockDatabase.Marshal
+var exportMagic = [4]byte{'V', 'S', 'D', 'B'}
+
+// exportVersion is bumped whenever the container layout changes.
+const exportVersion = 1
+
+// Upper bounds ImportVsMatcher accepts for the sizes stored in an export,
+// so a corrupt or hostile file cannot request huge allocations.
+const (
+	maxExportPatterns = 1 << 20 // patterns per export
+	maxExportPattern  = 1 << 16 // bytes per pattern
+	maxExportDatabase = 1 << 30 // bytes of serialized database
+)
+
+// Export writes the compiled database together with the pattern strings and
+// compile flags, so a precompiled ruleset can be shipped as one file and
+// loaded with ImportVsMatcher. The database is only valid on hosts with the
+// same Vectorscan version and CPU features.
+func (m *VsMatcher) Export(w io.Writer) error {
+	m.mu.Lock()
+	db, err := m.db.Marshal()
+	m.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to marshal database: %w", err)
+	}
+
+	bw := bufio.NewWriter(w)
+	bw.Write(exportMagic[:])
+	bw.WriteByte(exportVersion)
+	binary.Write(bw, binary.LittleEndian, uint32(m.flags))
+	binary.Write(bw, binary.LittleEndian, uint32(len(m.patterns)))
+	for _, p := range m.patterns {
+		binary.Write(bw, binary.LittleEndian, uint32(len(p)))
+		bw.WriteString(p)
+	}
+	binary.Write(bw, binary.LittleEndian, uint32(len(db)))
+	bw.Write(db)
+	return bw.Flush()
+}
+
+// DumpDatabase writes the raw serialized database, as produced by
+// hs.BlockDatabase.Marshal, without the Export container or the pattern
+// strings. Load it with hs.UnmarshalBlockDatabase or inspect it with
+// external tools.
+func (m *VsMatcher) DumpDatabase(w io.Writer) error {
+	m.mu.Lock()
+	db, err := m.db.Marshal()
+	m.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to marshal database: %w", err)
+	}
+
+	_, err = w.Write(db)
+	return err
+}
+
+// ImportVsMatcher loads a matcher written by Export without recompiling
+// the patterns. The database cannot be checked against the pattern list, so
+// if the file lists fewer patterns than the database holds, matches from the
+// extra IDs are still returned by Match but skipped by MatchAll and
+// MatchAllCounts.
+func ImportVsMatcher(r io.Reader) (*VsMatcher, error) {
+	br := bufio.NewReader(r)
+
+	var header struct {
+		Magic   [4]byte
+		Version uint8
+		Flags   uint32
+		Count   uint32
+	}
+	if err := binary.Read(br, binary.LittleEndian, &header); err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	if header.Magic != exportMagic {
+		return nil, errors.New("not a VsMatcher export")
+	}
+	if header.Version != exportVersion {
+		return nil, fmt.Errorf("unsupported export version %d", header.Version)
+	}
+
+	if header.Count == 0 {
+		return nil, errors.New("export has no patterns")
+	}
+	if header.Count > maxExportPatterns {
+		return nil, fmt.Errorf("pattern count %d exceeds limit of %d", header.Count, maxExportPatterns)
+	}
+
+	patterns := make([]string, 0, min(header.Count, 1024))
+	for i := range int(header.Count) {
+		b, err := readChunk(br, maxExportPattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read pattern %d: %w", i, err)
+		}
+		patterns = append(patterns, string(b))
+	}
+
+	data, err := readChunk(br, maxExportDatabase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read database: %w", err)
+	}
+	db, err := hs.UnmarshalBlockDatabase(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal database: %w", err)
+	}
+
+	scratch, err := hs.NewScratch(db)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to allocate scratch: %w", err)
+	}
+
+	return &VsMatcher{
+		db:       db,
+		scratch:  scratch,
+		patterns: patterns,
+		flags:    hs.CompileFlag(header.Flags),
+	}, nil
+}
+
+// readChunk reads a uint32 length followed by that many bytes. It fails if
+// the length exceeds limit, and grows the buffer only as data arrives so a
+// truncated stream does not cost the full claimed length.
+func readChunk(r io.Reader, limit uint32) ([]byte, error) {
+	var n uint32
+	if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+		return nil, err
+	}
+	if n > limit {
+		return nil, fmt.Errorf("length %d exceeds limit of %d", n, limit)
+	}
+	b, err := io.ReadAll(io.LimitReader(r, int64(n)))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != int(n) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return b, nil
+}
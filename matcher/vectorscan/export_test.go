@@ -0,0 +1,138 @@
+package vectorscan
+
+import (
+	"bytes"
+	"encoding/binary"
+	"slices"
+	"testing"
+
+	hs "github.com/flier/gohs/hyperscan"
+)
+
+func TestVsMatcher_ExportImport(t *testing.T) {
+	patterns := []string{`virus`, `trojan`, `\.exe$`}
+	m, err := NewVsMatcher(patterns)
+	if err != nil {
+		t.Fatalf("NewVsMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	var buf bytes.Buffer
+	if err := m.Export(&buf); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	imported, err := ImportVsMatcher(&buf)
+	if err != nil {
+		t.Fatalf("ImportVsMatcher failed: %v", err)
+	}
+	defer imported.Close()
+
+	if imported.PatternCount() != len(patterns) {
+		t.Errorf("PatternCount = %d, want %d", imported.PatternCount(), len(patterns))
+	}
+	for _, input := range []string{"virus.txt", "TROJAN.dll", "setup.exe", "clean.txt"} {
+		if got, want := imported.Match(input), m.Match(input); got != want {
+			t.Errorf("Match(%q): imported=%d, original=%d", input, got, want)
+		}
+	}
+
+	if _, err := ImportVsMatcher(bytes.NewReader([]byte("not an export"))); err == nil {
+		t.Error("expected error for invalid data")
+	}
+
+	// Headers claiming more patterns or longer chunks than the limits allow
+	// must be rejected before anything is allocated for them.
+	header := func(count, length uint32) []byte {
+		b := append(exportMagic[:], exportVersion)
+		b = binary.LittleEndian.AppendUint32(b, 0)
+		b = binary.LittleEndian.AppendUint32(b, count)
+		return binary.LittleEndian.AppendUint32(b, length)
+	}
+	for _, data := range [][]byte{
+		header(0, 0),
+		header(maxExportPatterns+1, 0),
+		header(1, maxExportPattern+1),
+		header(1, 100), // truncated pattern
+	} {
+		if _, err := ImportVsMatcher(bytes.NewReader(data)); err == nil {
+			t.Errorf("expected error for header %x", data)
+		}
+	}
+}
+
+// An export listing fewer patterns than its database holds still imports;
+// matches from the unlisted IDs must not index past the pattern list.
+func TestVsMatcher_ImportTruncatedPatterns(t *testing.T) {
+	m, err := NewVsMatcher([]string{`virus`, `trojan`, `\.exe$`})
+	if err != nil {
+		t.Fatalf("NewVsMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	var db bytes.Buffer
+	if err := m.DumpDatabase(&db); err != nil {
+		t.Fatalf("DumpDatabase failed: %v", err)
+	}
+
+	data := append(exportMagic[:], exportVersion)
+	data = binary.LittleEndian.AppendUint32(data, uint32(m.flags))
+	data = binary.LittleEndian.AppendUint32(data, 1)
+	data = binary.LittleEndian.AppendUint32(data, uint32(len("virus")))
+	data = append(data, "virus"...)
+	data = binary.LittleEndian.AppendUint32(data, uint32(db.Len()))
+	data = append(data, db.Bytes()...)
+
+	imported, err := ImportVsMatcher(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("ImportVsMatcher failed: %v", err)
+	}
+	defer imported.Close()
+
+	if got := imported.MatchAll("virus-trojan.exe"); !slices.Equal(got, []int{0}) {
+		t.Errorf("MatchAll = %v, want [0]", got)
+	}
+	if got := imported.MatchAllCounts("trojan.exe"); !slices.Equal(got, []int{0}) {
+		t.Errorf("MatchAllCounts = %v, want [0]", got)
+	}
+}
+
+func TestVsMatcher_DumpDatabase(t *testing.T) {
+	m, err := NewVsMatcher([]string{`virus`, `trojan`})
+	if err != nil {
+		t.Fatalf("NewVsMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	var buf bytes.Buffer
+	if err := m.DumpDatabase(&buf); err != nil {
+		t.Fatalf("DumpDatabase failed: %v", err)
+	}
+
+	db, err := hs.UnmarshalBlockDatabase(buf.Bytes())
+	if err != nil {
+		t.Fatalf("UnmarshalBlockDatabase failed: %v", err)
+	}
+	defer db.Close()
+
+	want, _ := m.DatabaseSize()
+	if got, err := db.Size(); err != nil || got != want {
+		t.Errorf("reloaded database size = (%d, %v), want %d", got, err, want)
+	}
+
+	scratch, err := hs.NewScratch(db)
+	if err != nil {
+		t.Fatalf("NewScratch failed: %v", err)
+	}
+	defer scratch.Free()
+
+	matched := -1
+	handler := hs.MatchHandler(func(id uint, from, to uint64, flags uint, context interface{}) error {
+		matched = int(id)
+		return hs.ErrScanTerminated
+	})
+	db.Scan([]byte("TROJAN.dll"), scratch, handler, nil)
+	if matched != 1 {
+		t.Errorf("reloaded database matched %d, want 1", matched)
+	}
+}
@@ -0,0 +1,54 @@
+package vectorscan
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// PatternSource records where a pattern loaded by NewVsMatcherFromFiles came
+// from. Line is 1-based.
+type PatternSource struct {
+	Path string
+	Line int
+}
+
+func (s PatternSource) String() string {
+	return fmt.Sprintf("%s:%d", s.Path, s.Line)
+}
+
+// NewVsMatcherFromFiles compiles the patterns of several rule files into one
+// matcher. Each file holds one pattern per line; blank lines and lines
+// starting with # are skipped, and surrounding whitespace is trimmed.
+// Patterns are numbered in file order, and the returned sources are parallel
+// to the pattern indices, so sources[id] gives the file and line of a match.
+// A compile error names the offending file and line.
+func NewVsMatcherFromFiles(paths []string) (*VsMatcher, []PatternSource, error) {
+	var patterns []string
+	var sources []PatternSource
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read patterns: %w", err)
+		}
+		for i, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			patterns = append(patterns, line)
+			sources = append(sources, PatternSource{Path: path, Line: i + 1})
+		}
+	}
+
+	m, err := NewVsMatcher(patterns)
+	if err != nil {
+		var ce *VsCompileError
+		if errors.As(err, &ce) {
+			return nil, nil, fmt.Errorf("%v: %w", sources[ce.Index], err)
+		}
+		return nil, nil, err
+	}
+	return m, sources, nil
+}
@@ -0,0 +1,51 @@
+package vectorscan
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewVsMatcherFromFiles(t *testing.T) {
+	dir := t.TempDir()
+	banking := filepath.Join(dir, "banking.rules")
+	ransom := filepath.Join(dir, "ransomware.rules")
+	if err := os.WriteFile(banking, []byte("# banking trojans\nemotet\n\ntrickbot\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(ransom, []byte("wannacry\n  lockbit  \n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	m, sources, err := NewVsMatcherFromFiles([]string{banking, ransom})
+	if err != nil {
+		t.Fatalf("NewVsMatcherFromFiles failed: %v", err)
+	}
+	defer m.Close()
+
+	if m.PatternCount() != 4 || len(sources) != 4 {
+		t.Fatalf("got %d patterns and %d sources, want 4 each", m.PatternCount(), len(sources))
+	}
+
+	tests := []struct {
+		input string
+		want  PatternSource
+	}{
+		{"/tmp/trickbot.dll", PatternSource{banking, 4}},
+		{"/tmp/lockbit_3.bin", PatternSource{ransom, 2}},
+	}
+	for _, tt := range tests {
+		id := m.Match(tt.input)
+		if id < 0 {
+			t.Errorf("Match(%q) found nothing", tt.input)
+			continue
+		}
+		if sources[id] != tt.want {
+			t.Errorf("Match(%q) source = %v, want %v", tt.input, sources[id], tt.want)
+		}
+	}
+
+	if _, _, err := NewVsMatcherFromFiles([]string{filepath.Join(dir, "missing.rules")}); err == nil {
+		t.Error("NewVsMatcherFromFiles with a missing file succeeded, want error")
+	}
+}
@@ -0,0 +1,128 @@
+package vectorscan
+
+import (
+	"regexp/syntax"
+	"unicode/utf8"
+)
+
+// MaxPatternLen returns the longest match, in bytes, that any pattern can
+// produce, or -1 if some pattern is unbounded (e.g. uses * or +). Use it to
+// size the overlap between chunks when scanning a stream in pieces.
+// Lengths are best-effort: patterns Go's regexp/syntax cannot parse count as
+// their literal string length.
+func (m *VsMatcher) MaxPatternLen() int {
+	longest := 0
+	for _, p := range m.patterns {
+		_, hi := patternLen(p)
+		if hi < 0 {
+			return -1
+		}
+		longest = max(longest, hi)
+	}
+	return longest
+}
+
+// MinPatternLen returns the shortest match, in bytes, that any pattern can
+// produce, or 0 if there are no patterns. It is best-effort in the same way
+// as MaxPatternLen.
+func (m *VsMatcher) MinPatternLen() int {
+	if len(m.patterns) == 0 {
+		return 0
+	}
+	shortest := -1
+	for _, p := range m.patterns {
+		lo, _ := patternLen(p)
+		if shortest < 0 || lo < shortest {
+			shortest = lo
+		}
+	}
+	return shortest
+}
+
+// patternLen returns the shortest and longest match of pattern in bytes, with
+// -1 for an unbounded maximum.
+func patternLen(pattern string) (lo, hi int) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return len(pattern), len(pattern)
+	}
+	return matchLen(re)
+}
+
+// matchLen walks a parsed regexp computing its match length bounds in bytes.
+func matchLen(re *syntax.Regexp) (lo, hi int) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			n := runeLen(r)
+			lo += n
+			hi += n
+		}
+		return lo, hi
+	case syntax.OpCharClass:
+		if len(re.Rune) == 0 {
+			return 0, 0
+		}
+		lo, hi = utf8.UTFMax, 0
+		for i := 0; i < len(re.Rune); i += 2 {
+			lo = min(lo, runeLen(re.Rune[i]))
+			hi = max(hi, runeLen(re.Rune[i+1]))
+		}
+		return lo, hi
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		return 1, utf8.UTFMax
+	case syntax.OpCapture:
+		return matchLen(re.Sub[0])
+	case syntax.OpStar:
+		return 0, -1
+	case syntax.OpPlus:
+		lo, _ = matchLen(re.Sub[0])
+		return lo, -1
+	case syntax.OpQuest:
+		_, hi = matchLen(re.Sub[0])
+		return 0, hi
+	case syntax.OpRepeat:
+		subLo, subHi := matchLen(re.Sub[0])
+		lo = subLo * re.Min
+		if re.Max < 0 || subHi < 0 {
+			return lo, -1
+		}
+		return lo, subHi * re.Max
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			subLo, subHi := matchLen(sub)
+			lo += subLo
+			if hi >= 0 {
+				if subHi < 0 {
+					hi = -1
+				} else {
+					hi += subHi
+				}
+			}
+		}
+		return lo, hi
+	case syntax.OpAlternate:
+		for i, sub := range re.Sub {
+			subLo, subHi := matchLen(sub)
+			if i == 0 || subLo < lo {
+				lo = subLo
+			}
+			if i == 0 || hi >= 0 && (subHi < 0 || subHi > hi) {
+				hi = subHi
+			}
+		}
+		return lo, hi
+	default:
+		// Empty matches and zero-width assertions
+		return 0, 0
+	}
+}
+
+// runeLen is utf8.RuneLen, counting surrogates (which appear as class range
+// endpoints but cannot be encoded) as three bytes.
+func runeLen(r rune) int {
+	if n := utf8.RuneLen(r); n > 0 {
+		return n
+	}
+	return 3
+}
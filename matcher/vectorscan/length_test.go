@@ -0,0 +1,31 @@
+package vectorscan
+
+import "testing"
+
+func TestVsMatcher_PatternLen(t *testing.T) {
+	m, err := NewVsMatcher([]string{`virus`, `troj(an|en)\.exe`, `[0-9]{3,5}`, `é`})
+	if err != nil {
+		t.Fatalf("NewVsMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	if got := m.MaxPatternLen(); got != 10 {
+		t.Errorf("MaxPatternLen = %d, want 10", got)
+	}
+	if got := m.MinPatternLen(); got != 2 {
+		t.Errorf("MinPatternLen = %d, want 2", got)
+	}
+
+	unbounded, err := NewVsMatcher([]string{`abc`, `x+y`})
+	if err != nil {
+		t.Fatalf("NewVsMatcher failed: %v", err)
+	}
+	defer unbounded.Close()
+
+	if got := unbounded.MaxPatternLen(); got != -1 {
+		t.Errorf("MaxPatternLen(unbounded) = %d, want -1", got)
+	}
+	if got := unbounded.MinPatternLen(); got != 2 {
+		t.Errorf("MinPatternLen(unbounded) = %d, want 2", got)
+	}
+}
@@ -13,24 +13,62 @@
 package vectorscan
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
+	"slices"
 	"sync"
 
 	hs "github.com/flier/gohs/hyperscan"
 )
 
+// defaultFlags are the compile flags applied to every pattern.
+const defaultFlags = hs.Caseless | hs.SingleMatch | hs.Utf8Mode
+
+// multiMatchFlags are defaultFlags without SingleMatch, so every occurrence of
+// a pattern is reported (NewVsMatcherMultiMatch).
+const multiMatchFlags = hs.Caseless | hs.Utf8Mode
+
 // VsMatcher implements multi-pattern matching using Vectorscan.
 // It compiles all patterns into a single database and matches them simultaneously.
 type VsMatcher struct {
 	db       hs.BlockDatabase
 	scratch  *hs.Scratch
 	patterns []string
+	flags    hs.CompileFlag
 	mu       sync.Mutex
+
+	// Leftmost start-of-match database, compiled on first use by the
+	// position APIs. SingleMatch cannot be combined with SOM tracking,
+	// so it lives alongside the main database rather than replacing it.
+	somDB      hs.BlockDatabase
+	somScratch *hs.Scratch
+
+	// Scan state reused by match and matchAll, guarded by mu. The handlers
+	// are method values bound on first use, so a scan does not allocate a
+	// new closure.
+	firstID      int
+	firstHandler hs.MatchHandler
+	seen         []bool
+	allIDs       []int
+	allHandler   hs.MatchHandler
 }
 
 // NewVsMatcher creates a new Vectorscan-based matcher from the given patterns.
 // Patterns are compiled into a block-mode database for simultaneous matching.
 func NewVsMatcher(patterns []string) (*VsMatcher, error) {
+	return newVsMatcher(patterns, defaultFlags)
+}
+
+// NewVsMatcherMultiMatch is like NewVsMatcher but compiles without
+// SingleMatch, so a pattern is reported each time it matches rather than
+// once per scan. Use it with MatchAllCounts to count occurrences. Scans are
+// slower than with NewVsMatcher since matching cannot stop early per pattern.
+func NewVsMatcherMultiMatch(patterns []string) (*VsMatcher, error) {
+	return newVsMatcher(patterns, multiMatchFlags)
+}
+
+func newVsMatcher(patterns []string, flags hs.CompileFlag) (*VsMatcher, error) {
 	if len(patterns) == 0 {
 		return nil, fmt.Errorf("no patterns provided")
 	}
@@ -40,7 +78,7 @@ func NewVsMatcher(patterns []string) (*VsMatcher, error) {
 	for i, p := range patterns {
 		vsPatterns[i] = &hs.Pattern{
 			Expression: p,
-			Flags:      hs.Caseless | hs.SingleMatch | hs.Utf8Mode,
+			Flags:      flags,
 			Id:         i,
 		}
 	}
@@ -48,7 +86,7 @@ func NewVsMatcher(patterns []string) (*VsMatcher, error) {
 	// Compile all patterns into a single database
 	db, err := hs.NewBlockDatabase(vsPatterns...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to compile patterns: %w", err)
+		return nil, fmt.Errorf("failed to compile patterns: %w", compileError(patterns, err))
 	}
 
 	// Allocate scratch space for scanning
@@ -62,52 +100,254 @@ func NewVsMatcher(patterns []string) (*VsMatcher, error) {
 		db:       db,
 		scratch:  scratch,
 		patterns: patterns,
+		flags:    flags,
 	}, nil
 }
 
+// VsCompileError identifies the pattern Vectorscan rejected and why, for
+// example "Backreferences are not supported".
+type VsCompileError struct {
+	Index      int    // position of the pattern in the input slice
+	Expression string // the rejected pattern
+	Message    string // Vectorscan's compiler message
+}
+
+func (e *VsCompileError) Error() string {
+	return fmt.Sprintf("pattern %d (%q): %s", e.Index, e.Expression, e.Message)
+}
+
+// compileError turns a failed multi-pattern compile into a VsCompileError
+// naming the pattern gohs reported. If the failure is not tied to one pattern
+// (e.g. the combined database is too large), err is returned as is.
+func compileError(patterns []string, err error) error {
+	var hsErr *hs.CompileError
+	if errors.As(err, &hsErr) && hsErr.Expression >= 0 && hsErr.Expression < len(patterns) {
+		i := hsErr.Expression
+		return &VsCompileError{Index: i, Expression: patterns[i], Message: hsErr.Message}
+	}
+	return err
+}
+
+// NewVsMatcherPrefixes creates a matcher that detects inputs starting with
+// any of the given literal prefixes, such as `C:\Windows\`. Each prefix is
+// escaped and anchored at the start of input, so regex metacharacters and
+// backslashes in paths need no quoting by the caller. Matching is
+// case-insensitive like every VsMatcher.
+func NewVsMatcherPrefixes(prefixes []string) (*VsMatcher, error) {
+	patterns := make([]string, len(prefixes))
+	for i, p := range prefixes {
+		patterns[i] = "^" + regexp.QuoteMeta(p)
+	}
+	return NewVsMatcher(patterns)
+}
+
+// NewVsMatcherWithSizeLimit is like NewVsMatcher but fails if the compiled
+// database is larger than maxBytes, so a rule set with too many expensive
+// patterns is rejected instead of loaded. The database and scratch space are
+// released before the error is returned.
+func NewVsMatcherWithSizeLimit(patterns []string, maxBytes int) (*VsMatcher, error) {
+	m, err := NewVsMatcher(patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := m.DatabaseSize()
+	if err != nil {
+		m.Close()
+		return nil, fmt.Errorf("failed to get database size: %w", err)
+	}
+	if size > maxBytes {
+		m.Close()
+		return nil, fmt.Errorf("database size %d bytes exceeds limit of %d bytes", size, maxBytes)
+	}
+	return m, nil
+}
+
+// MergeVsMatchers compiles the patterns of a and b into a new matcher.
+// Vectorscan cannot merge compiled databases, so the stored pattern strings
+// are recompiled. In the merged matcher a's patterns keep their indices and
+// b's follow them: b's pattern i becomes a.PatternCount() + i. a and b must
+// use the same compile flags and are left open.
+func MergeVsMatchers(a, b *VsMatcher) (*VsMatcher, error) {
+	if a.flags != b.flags {
+		return nil, fmt.Errorf("cannot merge matchers with different compile flags")
+	}
+
+	patterns := make([]string, 0, len(a.patterns)+len(b.patterns))
+	patterns = append(patterns, a.patterns...)
+	patterns = append(patterns, b.patterns...)
+	return newVsMatcher(patterns, a.flags)
+}
+
+// ValidatePattern trial-compiles a single pattern with the same flags
+// NewVsMatcher uses and reports whether Vectorscan accepts it.
+func ValidatePattern(pattern string) error {
+	db, err := hs.NewBlockDatabase(&hs.Pattern{
+		Expression: pattern,
+		Flags:      defaultFlags,
+	})
+	if err != nil {
+		return err
+	}
+	db.Close()
+	return nil
+}
+
 // Match returns the index of the first matching pattern, or -1 if no match.
 // All patterns are checked simultaneously - this is O(1) regardless of pattern count.
 func (m *VsMatcher) Match(input string) int {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	matchedID := -1
+	return m.match(input)
+}
+
+// match returns the first matching pattern. Callers must hold m.mu.
+func (m *VsMatcher) match(input string) int {
+	if m.firstHandler == nil {
+		m.firstHandler = m.onFirstMatch
+	}
+	m.firstID = -1
+
+	// Scan the input - ignoring ErrScanTerminated as it just means we found a match
+	err := m.db.Scan([]byte(input), m.scratch, m.firstHandler, nil)
+	if err != nil && err != hs.ErrScanTerminated {
+		return -1
+	}
+
+	return m.firstID
+}
+
+// onFirstMatch records the first match and stops the scan.
+func (m *VsMatcher) onFirstMatch(id uint, from, to uint64, flags uint, context interface{}) error {
+	m.firstID = int(id)
+	// Return error to stop scanning after first match
+	return hs.ErrScanTerminated
+}
 
-	// Scan with a handler that captures the first match
+// MatchAll returns indices of all matching patterns.
+// All patterns are checked simultaneously.
+func (m *VsMatcher) MatchAll(input string) []int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.matchAll(input)
+}
+
+// MatchAllBatch runs MatchAll on each input under a single lock acquisition
+// and returns the match IDs for each input, parallel to inputs.
+func (m *VsMatcher) MatchAllBatch(inputs []string) [][]int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	results := make([][]int, len(inputs))
+	for i, input := range inputs {
+		results[i] = m.matchAll(input)
+	}
+	return results
+}
+
+// matchAll scans input for all matching patterns. Callers must hold m.mu.
+func (m *VsMatcher) matchAll(input string) []int {
+	if m.allHandler == nil {
+		m.allHandler = m.onAnyMatch
+		m.seen = make([]bool, len(m.patterns))
+	}
+	clear(m.seen)
+	m.allIDs = m.allIDs[:0]
+
+	m.db.Scan([]byte(input), m.scratch, m.allHandler, nil)
+	if len(m.allIDs) == 0 {
+		return nil
+	}
+	return slices.Clone(m.allIDs)
+}
+
+// onAnyMatch records each pattern the first time it matches. IDs past the
+// pattern list, possible only in an inconsistent import, are ignored.
+func (m *VsMatcher) onAnyMatch(id uint, from, to uint64, flags uint, context interface{}) error {
+	if id < uint(len(m.seen)) && !m.seen[id] {
+		m.allIDs = append(m.allIDs, int(id))
+		m.seen[id] = true
+	}
+	return nil // Continue scanning
+}
+
+// MatchInSet returns the first pattern in allowed that matches input, or -1
+// if none does. Matches from other patterns are skipped and the scan goes on,
+// so one database can serve callers that are each enabled for a subset of
+// its patterns.
+func (m *VsMatcher) MatchInSet(input string, allowed map[int]bool) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	matchedID := -1
 	handler := hs.MatchHandler(func(id uint, from, to uint64, flags uint, context interface{}) error {
+		if !allowed[int(id)] {
+			return nil
+		}
 		matchedID = int(id)
-		// Return error to stop scanning after first match
 		return hs.ErrScanTerminated
 	})
 
-	// Scan the input - ignoring ErrScanTerminated as it just means we found a match
 	err := m.db.Scan([]byte(input), m.scratch, handler, nil)
 	if err != nil && err != hs.ErrScanTerminated {
 		return -1
 	}
-
 	return matchedID
 }
 
-// MatchAll returns indices of all matching patterns.
-// All patterns are checked simultaneously.
-func (m *VsMatcher) MatchAll(input string) []int {
+// MatchFunc scans input and calls fn for every match event with the pattern
+// index and the byte offsets of the match. Return false from fn to stop the
+// scan early. Without leftmost start-of-match tracking, start is always 0.
+// fn runs while the matcher is locked and must not call back into m.
+func (m *VsMatcher) MatchFunc(input string, fn func(id, start, end int) bool) error {
+	return m.MatchFuncFlags(input, func(id, start, end int, flags uint) bool {
+		return fn(id, start, end)
+	})
+}
+
+// MatchFuncFlags is like MatchFunc but also passes the raw flags value that
+// Vectorscan hands to the match handler. Current Vectorscan releases always
+// report 0, but the value is surfaced for callers that key on it.
+func (m *VsMatcher) MatchFuncFlags(input string, fn func(id, start, end int, flags uint) bool) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	var matches []int
-	seen := make(map[int]bool)
+	handler := hs.MatchHandler(func(id uint, from, to uint64, flags uint, context interface{}) error {
+		if !fn(int(id), int(from), int(to), flags) {
+			return hs.ErrScanTerminated
+		}
+		return nil
+	})
+
+	err := m.db.Scan([]byte(input), m.scratch, handler, nil)
+	if err != nil && err != hs.ErrScanTerminated {
+		return err
+	}
+	return nil
+}
+
+// MatchAllCounts returns, for each pattern index, how many times the pattern
+// was reported while scanning input. Vectorscan reports a match at each end
+// offset, so a literal counts once per occurrence while a pattern like `a+`
+// counts once per position where a match can end. On a matcher from
+// NewVsMatcher every count is 0 or 1; use NewVsMatcherMultiMatch to count
+// occurrences.
+func (m *VsMatcher) MatchAllCounts(input string) []int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
+	counts := make([]int, len(m.patterns))
 	handler := hs.MatchHandler(func(id uint, from, to uint64, flags uint, context interface{}) error {
-		if !seen[int(id)] {
-			matches = append(matches, int(id))
-			seen[int(id)] = true
+		if id < uint(len(counts)) {
+			counts[id]++
 		}
-		return nil // Continue scanning
+		return nil
 	})
 
 	m.db.Scan([]byte(input), m.scratch, handler, nil)
-	return matches
+	return counts
 }
 
 // PatternCount returns the number of patterns.
@@ -117,6 +357,12 @@ func (m *VsMatcher) PatternCount() int {
 
 // Close releases Vectorscan resources.
 func (m *VsMatcher) Close() {
+	if m.somScratch != nil {
+		m.somScratch.Free()
+	}
+	if m.somDB != nil {
+		m.somDB.Close()
+	}
 	if m.scratch != nil {
 		m.scratch.Free()
 	}
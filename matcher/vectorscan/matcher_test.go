@@ -1,7 +1,10 @@
 package vectorscan
 
 import (
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/paulstuart/cgo-ffi/matcher/testdata"
@@ -71,6 +74,50 @@ func TestVsMatcher_MatchAll(t *testing.T) {
 	}
 }
 
+func TestVsMatcher_MatchFuncFlags(t *testing.T) {
+	m, err := NewVsMatcher([]string{`error`, `fail`})
+	if err != nil {
+		t.Fatalf("NewVsMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	type event struct {
+		id, end int
+		flags   uint
+	}
+	var events []event
+	err = m.MatchFuncFlags("error then fail", func(id, start, end int, flags uint) bool {
+		events = append(events, event{id, end, flags})
+		return true
+	})
+	if err != nil {
+		t.Fatalf("MatchFuncFlags failed: %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("got %d match events, want 2: %+v", len(events), events)
+	}
+	if events[0].id != 0 || events[0].end != 5 {
+		t.Errorf("first event = %+v, want id=0 end=5", events[0])
+	}
+	if events[1].id != 1 || events[1].end != 15 {
+		t.Errorf("second event = %+v, want id=1 end=15", events[1])
+	}
+	for _, e := range events {
+		t.Logf("pattern %d flags=%d", e.id, e.flags)
+	}
+
+	// Returning false stops the scan after the first event
+	calls := 0
+	m.MatchFunc("error then fail", func(id, start, end int) bool {
+		calls++
+		return false
+	})
+	if calls != 1 {
+		t.Errorf("MatchFunc made %d calls after stop, want 1", calls)
+	}
+}
+
 func TestVsMatcher_DatabaseInfo(t *testing.T) {
 	patterns := []string{`test`, `pattern`}
 	m, err := NewVsMatcher(patterns)
@@ -151,6 +198,28 @@ func benchmarkVsMatch(b *testing.B, patternCount int) {
 	}
 }
 
+// Benchmark with varying input sizes, matching the GoMatcher sweep
+func BenchmarkVsMatcher_Match_ShortInput(b *testing.B)  { benchmarkVsInputSize(b, 100, 50) }
+func BenchmarkVsMatcher_Match_MediumInput(b *testing.B) { benchmarkVsInputSize(b, 100, 500) }
+func BenchmarkVsMatcher_Match_LongInput(b *testing.B)   { benchmarkVsInputSize(b, 100, 5000) }
+
+func benchmarkVsInputSize(b *testing.B, patternCount, inputLen int) {
+	patterns := generatePatterns(patternCount)
+	m, err := NewVsMatcher(patterns)
+	if err != nil {
+		b.Fatalf("NewVsMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	// Generate input of specified length with match near end
+	input := generateInput(inputLen, patternCount-1)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Match(input)
+	}
+}
+
 // Benchmark with match at different positions
 func BenchmarkVsMatcher_Match_FirstPattern(b *testing.B) {
 	m, err := NewVsMatcher(testdata.MalwarePatterns)
@@ -224,3 +293,322 @@ func generatePatterns(n int) []string {
 	}
 	return patterns
 }
+
+// generateInput creates a string of approximately len characters
+// that contains the specified pattern
+func generateInput(length, patternIdx int) string {
+	pattern := fmt.Sprintf("pattern_%d", patternIdx)
+	if length <= len(pattern) {
+		return pattern
+	}
+
+	// Pad with non-matching content, put pattern near end
+	padding := make([]byte, length-len(pattern)-1)
+	for i := range padding {
+		padding[i] = 'x'
+	}
+	return string(padding) + " " + pattern
+}
+
+// Benchmarks for database compilation with varying pattern counts
+func BenchmarkVsMatcher_Compile_10(b *testing.B)  { benchmarkVsCompile(b, 10) }
+func BenchmarkVsMatcher_Compile_100(b *testing.B) { benchmarkVsCompile(b, 100) }
+func BenchmarkVsMatcher_Compile_256(b *testing.B) { benchmarkVsCompile(b, 256) }
+
+func benchmarkVsCompile(b *testing.B, patternCount int) {
+	if patternCount > len(testdata.MalwarePatterns) {
+		patternCount = len(testdata.MalwarePatterns)
+	}
+	patterns := testdata.MalwarePatterns[:patternCount]
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m, err := NewVsMatcher(patterns)
+		if err != nil {
+			b.Fatalf("NewVsMatcher failed: %v", err)
+		}
+		m.Close()
+	}
+}
+
+func TestVsMatcher_Prefixes(t *testing.T) {
+	m, err := NewVsMatcherPrefixes([]string{`C:\Windows\`, `C:\Program Files (x86)\`})
+	if err != nil {
+		t.Fatalf("NewVsMatcherPrefixes failed: %v", err)
+	}
+	defer m.Close()
+
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{`C:\Windows\System32\drivers\evil.sys`, 0},
+		{`c:\windows\temp\x.dll`, 0},
+		{`C:\Program Files (x86)\app\run.exe`, 1},
+		{`D:\backup\C:\Windows\copy.txt`, -1}, // not at start
+		{`C:\WindowsOld\file.txt`, -1},
+	}
+
+	for _, tt := range tests {
+		if got := m.Match(tt.input); got != tt.want {
+			t.Errorf("Match(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestVsMatcher_MatchAllBatch(t *testing.T) {
+	m, err := NewVsMatcher([]string{`virus`, `trojan`, `\.exe$`})
+	if err != nil {
+		t.Fatalf("NewVsMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	inputs := []string{"virus_trojan.exe", "/usr/bin/ls", "trojan.dll"}
+	results := m.MatchAllBatch(inputs)
+	if len(results) != len(inputs) {
+		t.Fatalf("MatchAllBatch returned %d results, want %d", len(results), len(inputs))
+	}
+	for i, input := range inputs {
+		want := m.MatchAll(input)
+		if fmt.Sprint(results[i]) != fmt.Sprint(want) {
+			t.Errorf("MatchAllBatch[%d] (%q) = %v, want %v", i, input, results[i], want)
+		}
+	}
+}
+
+// Benchmark batched MatchAll against a loop over MatchAll
+func BenchmarkVsMatcher_MatchAllBatch(b *testing.B) {
+	m, err := NewVsMatcher(testdata.MalwarePatterns)
+	if err != nil {
+		b.Fatalf("NewVsMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.MatchAllBatch(testdata.TestFilenames)
+	}
+}
+
+func BenchmarkVsMatcher_MatchAllLoop(b *testing.B) {
+	m, err := NewVsMatcher(testdata.MalwarePatterns)
+	if err != nil {
+		b.Fatalf("NewVsMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, f := range testdata.TestFilenames {
+			m.MatchAll(f)
+		}
+	}
+}
+
+func TestVsMatcher_CompileError(t *testing.T) {
+	// Vectorscan does not support backreferences
+	_, err := NewVsMatcher([]string{`virus`, `(a)\1`})
+	if err == nil {
+		t.Fatal("expected compile error for backreference")
+	}
+
+	var compileErr *VsCompileError
+	if !errors.As(err, &compileErr) {
+		t.Fatalf("error %v is not a *VsCompileError", err)
+	}
+	if compileErr.Index != 1 || compileErr.Expression != `(a)\1` {
+		t.Errorf("VsCompileError = {%d, %q}, want {1, %q}", compileErr.Index, compileErr.Expression, `(a)\1`)
+	}
+	if compileErr.Message == "" {
+		t.Error("VsCompileError.Message is empty")
+	}
+}
+
+func TestVsMatcher_MultiMatchCounts(t *testing.T) {
+	patterns := []string{`ab`, `xyz`}
+	input := "ab ab AB"
+
+	single, err := NewVsMatcher(patterns)
+	if err != nil {
+		t.Fatalf("NewVsMatcher failed: %v", err)
+	}
+	defer single.Close()
+	multi, err := NewVsMatcherMultiMatch(patterns)
+	if err != nil {
+		t.Fatalf("NewVsMatcherMultiMatch failed: %v", err)
+	}
+	defer multi.Close()
+
+	if got, want := single.MatchAllCounts(input), []int{1, 0}; !slices.Equal(got, want) {
+		t.Errorf("single-match MatchAllCounts = %v, want %v", got, want)
+	}
+	if got, want := multi.MatchAllCounts(input), []int{3, 0}; !slices.Equal(got, want) {
+		t.Errorf("multi-match MatchAllCounts = %v, want %v", got, want)
+	}
+	if got, want := multi.MatchAll(input), []int{0}; !slices.Equal(got, want) {
+		t.Errorf("multi-match MatchAll = %v, want %v", got, want)
+	}
+}
+
+func TestVsMatcher_ReusedHandler(t *testing.T) {
+	m, err := NewVsMatcher([]string{`foo`, `bar`, `baz`})
+	if err != nil {
+		t.Fatalf("NewVsMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	// Alternate inputs so stale state from one scan would show up in the next
+	for i := 0; i < 3; i++ {
+		if got := m.Match("xx bar"); got != 1 {
+			t.Errorf("round %d: Match(xx bar) = %d, want 1", i, got)
+		}
+		if got := m.Match("nothing"); got != -1 {
+			t.Errorf("round %d: Match(nothing) = %d, want -1", i, got)
+		}
+		if got := m.MatchAll("baz foo baz"); !slices.Equal(got, []int{2, 0}) {
+			t.Errorf("round %d: MatchAll(baz foo baz) = %v, want [2 0]", i, got)
+		}
+		if got := m.MatchAll("nothing"); got != nil {
+			t.Errorf("round %d: MatchAll(nothing) = %v, want nil", i, got)
+		}
+	}
+
+	// Returned slices must not alias the reused buffer
+	first := m.MatchAll("foo bar")
+	_ = m.MatchAll("baz")
+	if !slices.Equal(first, []int{0, 1}) {
+		t.Errorf("MatchAll result changed by a later scan: %v", first)
+	}
+}
+
+// Benchmark allocations on the Match hot path. The handler is reused across
+// calls, so what remains is the input conversion plus gohs's own per-scan
+// cost: its Scan boxes the handler and context in a cgo handle on every call.
+func BenchmarkVsMatcher_Match_Allocs(b *testing.B) {
+	m, err := NewVsMatcher(testdata.MalwarePatterns)
+	if err != nil {
+		b.Fatalf("NewVsMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	input := `/tmp/cache/emotet_12345.bin`
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Match(input)
+	}
+}
+
+func TestVsMatcher_MatchInSet(t *testing.T) {
+	m, err := NewVsMatcher([]string{`alpha`, `beta`, `gamma`})
+	if err != nil {
+		t.Fatalf("NewVsMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	// alpha is the first raw match but is not allowed
+	input := "alpha then gamma"
+	if got := m.Match(input); got != 0 {
+		t.Fatalf("Match(%q) = %d, want 0", input, got)
+	}
+	if got := m.MatchInSet(input, map[int]bool{2: true}); got != 2 {
+		t.Errorf("MatchInSet(%q, {2}) = %d, want 2", input, got)
+	}
+	if got := m.MatchInSet(input, map[int]bool{1: true}); got != -1 {
+		t.Errorf("MatchInSet(%q, {1}) = %d, want -1", input, got)
+	}
+	if got := m.MatchInSet(input, nil); got != -1 {
+		t.Errorf("MatchInSet(%q, nil) = %d, want -1", input, got)
+	}
+}
+
+// Benchmark MatchAll on an input that hits every pattern. The ID buffer is
+// reused across scans, so in steady state only the returned copy and the
+// per-scan cost inside gohs allocate
+func BenchmarkVsMatcher_MatchAll_ManyMatches(b *testing.B) {
+	patterns := generatePatterns(48)
+	m, err := NewVsMatcher(patterns)
+	if err != nil {
+		b.Fatalf("NewVsMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	input := strings.Join(patterns, " ")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.MatchAll(input)
+	}
+}
+
+func TestVsMatcher_SizeLimit(t *testing.T) {
+	patterns := generatePatterns(50)
+
+	m, err := NewVsMatcherWithSizeLimit(patterns, 16)
+	if err == nil {
+		m.Close()
+		t.Fatal("NewVsMatcherWithSizeLimit with a 16-byte limit succeeded, want error")
+	}
+	if m != nil {
+		t.Errorf("NewVsMatcherWithSizeLimit returned a matcher along with error %v", err)
+	}
+	if !strings.Contains(err.Error(), "exceeds limit of 16 bytes") {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	m, err = NewVsMatcherWithSizeLimit(patterns, 64<<20)
+	if err != nil {
+		t.Fatalf("NewVsMatcherWithSizeLimit with a 64 MiB limit failed: %v", err)
+	}
+	defer m.Close()
+	if got := m.Match("pattern_7"); got != 7 {
+		t.Errorf("Match(pattern_7) = %d, want 7", got)
+	}
+}
+
+func TestMergeVsMatchers(t *testing.T) {
+	a, err := NewVsMatcher([]string{`emotet`, `trickbot`})
+	if err != nil {
+		t.Fatalf("NewVsMatcher(a) failed: %v", err)
+	}
+	defer a.Close()
+	b, err := NewVsMatcher([]string{`wannacry`, `lockbit`})
+	if err != nil {
+		t.Fatalf("NewVsMatcher(b) failed: %v", err)
+	}
+	defer b.Close()
+
+	m, err := MergeVsMatchers(a, b)
+	if err != nil {
+		t.Fatalf("MergeVsMatchers failed: %v", err)
+	}
+	defer m.Close()
+
+	if m.PatternCount() != 4 {
+		t.Errorf("PatternCount = %d, want 4", m.PatternCount())
+	}
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"/tmp/trickbot.dll", 1},      // a's index unchanged
+		{"/tmp/lockbit_3.bin", 2 + 1}, // b's index shifted by a.PatternCount()
+		{"/usr/bin/ls", -1},
+	}
+	for _, tt := range tests {
+		if got := m.Match(tt.input); got != tt.want {
+			t.Errorf("Match(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+
+	multi, err := NewVsMatcherMultiMatch([]string{`virus`})
+	if err != nil {
+		t.Fatalf("NewVsMatcherMultiMatch failed: %v", err)
+	}
+	defer multi.Close()
+	if _, err := MergeVsMatchers(a, multi); err == nil {
+		t.Error("MergeVsMatchers with different flags succeeded, want error")
+	}
+}
@@ -0,0 +1,201 @@
+package vectorscan
+
+import (
+	"fmt"
+	"iter"
+	"unicode/utf8"
+
+	hs "github.com/flier/gohs/hyperscan"
+)
+
+// somFlags are the compile flags for the start-of-match database. They match
+// defaultFlags except that SingleMatch is replaced by SomLeftMost.
+const somFlags = hs.Caseless | hs.Utf8Mode | hs.SomLeftMost
+
+// somDatabase compiles the start-of-match database on first use.
+// Callers must hold m.mu.
+func (m *VsMatcher) somDatabase() error {
+	if m.somDB != nil {
+		return nil
+	}
+
+	vsPatterns := make([]*hs.Pattern, len(m.patterns))
+	for i, p := range m.patterns {
+		vsPatterns[i] = &hs.Pattern{
+			Expression: p,
+			Flags:      somFlags,
+			Id:         i,
+		}
+	}
+
+	db, err := hs.NewBlockDatabase(vsPatterns...)
+	if err != nil {
+		return fmt.Errorf("failed to compile position database: %w", err)
+	}
+
+	scratch, err := hs.NewScratch(db)
+	if err != nil {
+		db.Close()
+		return fmt.Errorf("failed to allocate position scratch: %w", err)
+	}
+
+	m.somDB = db
+	m.somScratch = scratch
+	return nil
+}
+
+// MatchPositions returns the index of the first matching pattern along with
+// the byte offsets of the match, or (-1, -1, -1) if nothing matches.
+// Start offsets are leftmost, which requires a second database; it is
+// compiled on the first call.
+func (m *VsMatcher) MatchPositions(input string) (id, start, end int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id, start, end = -1, -1, -1
+	if err := m.somDatabase(); err != nil {
+		return id, start, end
+	}
+
+	handler := hs.MatchHandler(func(matchID uint, from, to uint64, flags uint, context interface{}) error {
+		id, start, end = int(matchID), int(from), int(to)
+		return hs.ErrScanTerminated
+	})
+
+	err := m.somDB.Scan([]byte(input), m.somScratch, handler, nil)
+	if err != nil && err != hs.ErrScanTerminated {
+		return -1, -1, -1
+	}
+	return id, start, end
+}
+
+// MatchPositionsRunes is like MatchPositions but reports rune offsets instead
+// of byte offsets, for highlighting matches in text with multi-byte characters.
+func (m *VsMatcher) MatchPositionsRunes(input string) (id, startRune, endRune int) {
+	id, start, end := m.MatchPositions(input)
+	if id < 0 {
+		return -1, -1, -1
+	}
+	startRune = utf8.RuneCountInString(input[:start])
+	endRune = startRune + utf8.RuneCountInString(input[start:end])
+	return id, startRune, endRune
+}
+
+// FirstMatchOffset returns the leftmost start offset matched by any pattern
+// and the pattern that matched there, or (-1, -1) if nothing matches. Unlike
+// MatchPositions, the whole input is scanned, since Vectorscan reports
+// matches in end-offset order and a later report may start earlier. Ties go
+// to the lower pattern index.
+func (m *VsMatcher) FirstMatchOffset(input string) (offset, patternID int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	offset, patternID = -1, -1
+	if err := m.somDatabase(); err != nil {
+		return -1, -1
+	}
+
+	handler := hs.MatchHandler(func(id uint, from, to uint64, flags uint, context interface{}) error {
+		start := int(from)
+		if offset < 0 || start < offset || (start == offset && int(id) < patternID) {
+			offset, patternID = start, int(id)
+		}
+		return nil
+	})
+
+	if err := m.somDB.Scan([]byte(input), m.somScratch, handler, nil); err != nil {
+		return -1, -1
+	}
+	return offset, patternID
+}
+
+// MatchResult is one match reported by MatchAllPositions, with byte offsets
+// into the input. Input is only set by ScanChannel, whose results arrive out
+// of order.
+type MatchResult struct {
+	ID    int    `json:"id"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Input string `json:"input,omitempty"`
+}
+
+// MatchAllPositions returns every match Vectorscan reports for input, in the
+// order reported (by end offset). A pattern that matches more than once
+// appears once per match. It returns nil if nothing matches.
+func (m *VsMatcher) MatchAllPositions(input string) []MatchResult {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.somDatabase(); err != nil {
+		return nil
+	}
+
+	var results []MatchResult
+	handler := hs.MatchHandler(func(id uint, from, to uint64, flags uint, context interface{}) error {
+		results = append(results, MatchResult{ID: int(id), Start: int(from), End: int(to)})
+		return nil
+	})
+
+	if err := m.somDB.Scan([]byte(input), m.somScratch, handler, nil); err != nil {
+		return nil
+	}
+	return results
+}
+
+// Range is a span of byte offsets into the input, end exclusive.
+type Range struct {
+	Start int
+	End   int
+}
+
+// MatchMap groups the results of MatchAllPositions by pattern index, for
+// highlighting each rule's hits separately. Ranges for a pattern are in the
+// order reported. It returns an empty map if nothing matches.
+func (m *VsMatcher) MatchMap(input string) map[int][]Range {
+	ranges := make(map[int][]Range)
+	for _, r := range m.MatchAllPositions(input) {
+		ranges[r.ID] = append(ranges[r.ID], Range{Start: r.Start, End: r.End})
+	}
+	return ranges
+}
+
+// MatchSeq returns an iterator over the matches MatchAllPositions would
+// return, without collecting them. Vectorscan scans synchronously and reports
+// each match through a callback, so the iterator calls yield directly from
+// that callback: no buffering and no goroutine, and breaking out of the loop
+// terminates the scan. The matcher stays locked for the whole loop, so the
+// loop body must not call other methods on the same matcher.
+//
+// A panic in the loop body is caught inside the callback, the scan is
+// terminated, and the panic is raised again once the scan has returned, so
+// it never unwinds through Vectorscan's C frames. runtime.Goexit cannot be
+// intercepted that way: calling it from the loop body leaves the scratch
+// space marked in use and breaks later position scans on the matcher.
+func (m *VsMatcher) MatchSeq(input string) iter.Seq[MatchResult] {
+	return func(yield func(MatchResult) bool) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		if err := m.somDatabase(); err != nil {
+			return
+		}
+
+		var panicked any
+		handler := hs.MatchHandler(func(id uint, from, to uint64, flags uint, context interface{}) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					panicked, err = r, hs.ErrScanTerminated
+				}
+			}()
+			if !yield(MatchResult{ID: int(id), Start: int(from), End: int(to)}) {
+				return hs.ErrScanTerminated
+			}
+			return nil
+		})
+
+		m.somDB.Scan([]byte(input), m.somScratch, handler, nil)
+		if panicked != nil {
+			panic(panicked)
+		}
+	}
+}
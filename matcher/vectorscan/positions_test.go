@@ -0,0 +1,144 @@
+package vectorscan
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestVsMatcher_MatchPositions(t *testing.T) {
+	m, err := NewVsMatcher([]string{`virus`, `trojan`})
+	if err != nil {
+		t.Fatalf("NewVsMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	id, start, end := m.MatchPositions("/tmp/trojan.exe")
+	if id != 1 || start != 5 || end != 11 {
+		t.Errorf("MatchPositions = (%d, %d, %d), want (1, 5, 11)", id, start, end)
+	}
+
+	id, start, end = m.MatchPositions("/usr/bin/ls")
+	if id != -1 || start != -1 || end != -1 {
+		t.Errorf("MatchPositions(no match) = (%d, %d, %d), want (-1, -1, -1)", id, start, end)
+	}
+}
+
+func TestVsMatcher_MatchPositionsRunes(t *testing.T) {
+	m, err := NewVsMatcher([]string{`trojan`})
+	if err != nil {
+		t.Fatalf("NewVsMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	// The emoji is 4 bytes but a single rune
+	input := "🔥 trojan"
+
+	_, byteStart, byteEnd := m.MatchPositions(input)
+	if byteStart != 5 || byteEnd != 11 {
+		t.Errorf("byte offsets = (%d, %d), want (5, 11)", byteStart, byteEnd)
+	}
+
+	id, start, end := m.MatchPositionsRunes(input)
+	if id != 0 || start != 2 || end != 8 {
+		t.Errorf("MatchPositionsRunes = (%d, %d, %d), want (0, 2, 8)", id, start, end)
+	}
+	if got := string([]rune(input)[start:end]); got != "trojan" {
+		t.Errorf("rune slice = %q, want %q", got, "trojan")
+	}
+}
+
+func TestVsMatcher_FirstMatchOffset(t *testing.T) {
+	m, err := NewVsMatcher([]string{`password=\w+`, `user`})
+	if err != nil {
+		t.Fatalf("NewVsMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	// Pattern 1 starts earlier even though pattern 0 is listed first
+	offset, id := m.FirstMatchOffset("login user=bob password=hunter2")
+	if offset != 6 || id != 1 {
+		t.Errorf("FirstMatchOffset = (%d, %d), want (6, 1)", offset, id)
+	}
+
+	offset, id = m.FirstMatchOffset("nothing to redact")
+	if offset != -1 || id != -1 {
+		t.Errorf("FirstMatchOffset(no match) = (%d, %d), want (-1, -1)", offset, id)
+	}
+}
+
+func TestVsMatcher_MatchMap(t *testing.T) {
+	m, err := NewVsMatcher([]string{`foo`, `bar`})
+	if err != nil {
+		t.Fatalf("NewVsMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	got := m.MatchMap("foo bar foo bar")
+	want := map[int][]Range{
+		0: {{0, 3}, {8, 11}},
+		1: {{4, 7}, {12, 15}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MatchMap = %v, want %v", got, want)
+	}
+
+	if got := m.MatchMap("baz"); len(got) != 0 {
+		t.Errorf("MatchMap(no match) = %v, want empty", got)
+	}
+}
+
+func TestVsMatcher_MatchSeq(t *testing.T) {
+	m, err := NewVsMatcher([]string{`foo`, `bar`})
+	if err != nil {
+		t.Fatalf("NewVsMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	input := "foo bar foo bar"
+	var all []MatchResult
+	for r := range m.MatchSeq(input) {
+		all = append(all, r)
+	}
+	if want := m.MatchAllPositions(input); !reflect.DeepEqual(all, want) {
+		t.Errorf("MatchSeq = %v, want %v", all, want)
+	}
+
+	var first []MatchResult
+	for r := range m.MatchSeq(input) {
+		first = append(first, r)
+		break
+	}
+	if want := []MatchResult{{ID: 0, Start: 0, End: 3}}; !reflect.DeepEqual(first, want) {
+		t.Errorf("MatchSeq with break = %v, want %v", first, want)
+	}
+
+	// The lock is released once the loop exits
+	if got := m.Match("bar"); got != 1 {
+		t.Errorf("Match after MatchSeq = %d, want 1", got)
+	}
+}
+
+func TestVsMatcher_MatchSeqPanic(t *testing.T) {
+	m, err := NewVsMatcher([]string{`foo`, `bar`})
+	if err != nil {
+		t.Fatalf("NewVsMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	// A panic in the loop body comes back out of the loop intact
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("recovered %v, want boom", r)
+			}
+		}()
+		for range m.MatchSeq("foo bar") {
+			panic("boom")
+		}
+	}()
+
+	// The scratch space was released, so position scans still work
+	if got := m.MatchAllPositions("bar"); len(got) != 1 || got[0].ID != 1 {
+		t.Errorf("MatchAllPositions after panic = %v, want one match of pattern 1", got)
+	}
+}
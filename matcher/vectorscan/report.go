@@ -0,0 +1,112 @@
+package vectorscan
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// MatchReport bundles an input with its positioned matches, for passing scan
+// results between services. It encodes as JSON through the struct tags, or
+// compactly with MarshalBinary.
+type MatchReport struct {
+	Input   string        `json:"input"`
+	Matches []MatchResult `json:"matches"`
+}
+
+// Report scans input with MatchAllPositions and wraps the result.
+func (m *VsMatcher) Report(input string) MatchReport {
+	return MatchReport{Input: input, Matches: m.MatchAllPositions(input)}
+}
+
+// Binary report layout, all integers unsigned varints:
+//
+//	version  byte
+//	input    length, bytes
+//	count    number of matches
+//	matches  count × (id, start, end-start)
+const reportVersion = 1
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (r MatchReport) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, 1+2*binary.MaxVarintLen64+len(r.Input)+3*len(r.Matches))
+	buf = append(buf, reportVersion)
+	buf = binary.AppendUvarint(buf, uint64(len(r.Input)))
+	buf = append(buf, r.Input...)
+	buf = binary.AppendUvarint(buf, uint64(len(r.Matches)))
+	for i, m := range r.Matches {
+		if m.ID < 0 || m.Start < 0 || m.End < m.Start {
+			return nil, fmt.Errorf("match %d: invalid offsets (%d, %d, %d)", i, m.ID, m.Start, m.End)
+		}
+		buf = binary.AppendUvarint(buf, uint64(m.ID))
+		buf = binary.AppendUvarint(buf, uint64(m.Start))
+		buf = binary.AppendUvarint(buf, uint64(m.End-m.Start))
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (r *MatchReport) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("empty match report")
+	}
+	if data[0] != reportVersion {
+		return fmt.Errorf("unsupported match report version %d", data[0])
+	}
+	data = data[1:]
+
+	next := func() (int, error) {
+		v, n := binary.Uvarint(data)
+		if n <= 0 || v > math.MaxInt32 {
+			return 0, errors.New("truncated match report")
+		}
+		data = data[n:]
+		return int(v), nil
+	}
+
+	n, err := next()
+	if err != nil {
+		return err
+	}
+	if n > len(data) {
+		return errors.New("truncated match report")
+	}
+	input := string(data[:n])
+	data = data[n:]
+
+	count, err := next()
+	if err != nil {
+		return err
+	}
+	// Every match takes at least three bytes
+	if count > len(data)/3 {
+		return errors.New("truncated match report")
+	}
+
+	var matches []MatchResult
+	if count > 0 {
+		matches = make([]MatchResult, count)
+	}
+	for i := range matches {
+		id, err := next()
+		if err != nil {
+			return err
+		}
+		start, err := next()
+		if err != nil {
+			return err
+		}
+		length, err := next()
+		if err != nil {
+			return err
+		}
+		matches[i] = MatchResult{ID: id, Start: start, End: start + length}
+	}
+	if len(data) != 0 {
+		return fmt.Errorf("%d trailing bytes after match report", len(data))
+	}
+
+	r.Input, r.Matches = input, matches
+	return nil
+}
@@ -0,0 +1,48 @@
+package vectorscan
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatchReport_BinaryRoundTrip(t *testing.T) {
+	reports := []MatchReport{
+		{Input: "/tmp/trojan.exe", Matches: []MatchResult{{ID: 1, Start: 5, End: 11}, {ID: 300, Start: 0, End: 15}}},
+		{Input: "héllo wörld", Matches: []MatchResult{{ID: 0, Start: 7, End: 13}}},
+		{Input: "no match"},
+		{},
+	}
+	for _, want := range reports {
+		data, err := want.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary(%q) failed: %v", want.Input, err)
+		}
+		var got MatchReport
+		if err := got.UnmarshalBinary(data); err != nil {
+			t.Fatalf("UnmarshalBinary(%q) failed: %v", want.Input, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("round trip = %+v, want %+v", got, want)
+		}
+
+		if len(data) > 1 {
+			if err := got.UnmarshalBinary(data[:len(data)-1]); err == nil {
+				t.Errorf("UnmarshalBinary(%q) accepted truncated data", want.Input)
+			}
+		}
+	}
+}
+
+func TestVsMatcher_Report(t *testing.T) {
+	m, err := NewVsMatcher([]string{`virus`, `trojan`})
+	if err != nil {
+		t.Fatalf("NewVsMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	got := m.Report("/tmp/trojan.exe")
+	want := MatchReport{Input: "/tmp/trojan.exe", Matches: []MatchResult{{ID: 1, Start: 5, End: 11}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Report = %+v, want %+v", got, want)
+	}
+}
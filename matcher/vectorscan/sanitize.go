@@ -0,0 +1,88 @@
+package vectorscan
+
+import "strings"
+
+// Sanitize rewrites Go regexp syntax that Vectorscan rejects or treats
+// differently into an equivalent Vectorscan form where one exists, and reports
+// whether the result compiles with the flags NewVsMatcher uses. It helps
+// migrate rule sets written for GoMatcher.
+//
+// Rewrites, applied outside character classes:
+//
+//	\A       → ^   (equivalent unless multi-line mode is on)
+//	\z       → $
+//	(?U)     → removed; Vectorscan has no ungreedy flag, and since it reports
+//	           every match end, greediness does not change which inputs match
+//	(?iU:x)  → (?i:x), likewise for other flag groups containing U
+//
+// Constructs with no Vectorscan equivalent are left alone and make the
+// second result false.
+func Sanitize(pattern string) (string, bool) {
+	var b strings.Builder
+	inClass := false
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '\\' && i+1 < len(pattern):
+			next := pattern[i+1]
+			i++
+			switch {
+			case !inClass && next == 'A':
+				b.WriteByte('^')
+			case !inClass && next == 'z':
+				b.WriteByte('$')
+			default:
+				b.WriteByte(c)
+				b.WriteByte(next)
+			}
+		case inClass:
+			if c == ']' {
+				inClass = false
+			}
+			b.WriteByte(c)
+		case c == '[':
+			inClass = true
+			b.WriteByte(c)
+			// A ']' straight after '[' or '[^' is a literal
+			if i+1 < len(pattern) && pattern[i+1] == '^' {
+				b.WriteByte('^')
+				i++
+			}
+			if i+1 < len(pattern) && pattern[i+1] == ']' {
+				b.WriteByte(']')
+				i++
+			}
+		case c == '(' && strings.HasPrefix(pattern[i:], "(?"):
+			n, group := sanitizeFlags(pattern[i:])
+			b.WriteString(group)
+			i += n - 1
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	sanitized := b.String()
+	return sanitized, ValidatePattern(sanitized) == nil
+}
+
+// sanitizeFlags rewrites an inline flag group at the start of s, such as
+// "(?iU)" or "(?U:", dropping the U flag. It returns how many bytes of s the
+// group spans and its replacement. Groups that are not flag groups (named
+// captures, for instance) are returned unchanged.
+func sanitizeFlags(s string) (int, string) {
+	end := strings.IndexAny(s, ":)")
+	if end < 0 {
+		return 2, "(?"
+	}
+	flags := s[2:end]
+	if strings.Trim(flags, "imsU-") != "" || !strings.Contains(flags, "U") {
+		return 2, "(?"
+	}
+
+	flags = strings.ReplaceAll(flags, "U", "")
+	flags = strings.TrimSuffix(flags, "-")
+	if flags == "" && s[end] == ')' {
+		return end + 1, ""
+	}
+	return end + 1, "(?" + flags + s[end:end+1]
+}
@@ -0,0 +1,27 @@
+package vectorscan
+
+import "testing"
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+		ok      bool
+	}{
+		{`\Avirus\z`, `^virus$`, true},
+		{`(?U)a+b`, `a+b`, true},
+		{`(?iU:trojan)`, `(?i:trojan)`, true},
+		{`(?i-U)worm`, `(?i)worm`, true},
+		{`(?U:x)y`, `(?:x)y`, true},
+		{`[^]z]\z`, `[^]z]$`, true},
+		{`a\\z`, `a\\z`, true},
+		{`(?P<name>abc)`, `(?P<name>abc)`, true},
+		{`(a)\1`, `(a)\1`, false},
+	}
+	for _, tt := range tests {
+		got, ok := Sanitize(tt.pattern)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Sanitize(%q) = (%q, %v), want (%q, %v)", tt.pattern, got, ok, tt.want, tt.ok)
+		}
+	}
+}
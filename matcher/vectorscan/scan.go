@@ -0,0 +1,125 @@
+package vectorscan
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	hs "github.com/flier/gohs/hyperscan"
+)
+
+// progressInterval is how many inputs ScanAll scans between progress reports.
+const progressInterval = 100
+
+// ScanAll runs Match on each input and returns the results parallel to
+// inputs. If progress is non-nil it is called every progressInterval inputs
+// and once more when the scan completes. When ctx is canceled the scan stops
+// before the next input and returns the results so far along with ctx.Err().
+// The matcher is locked per input, so other callers can interleave.
+func (m *VsMatcher) ScanAll(ctx context.Context, inputs []string, progress func(done, total int)) ([]int, error) {
+	results := make([]int, 0, len(inputs))
+	for i, input := range inputs {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		results = append(results, m.Match(input))
+
+		if progress != nil && (i+1)%progressInterval == 0 && i+1 < len(inputs) {
+			progress(i+1, len(inputs))
+		}
+	}
+	if progress != nil {
+		progress(len(inputs), len(inputs))
+	}
+	return results, nil
+}
+
+// NotScanned marks inputs MatchBatchDeadline did not reach before its deadline.
+const NotScanned = -2
+
+// MatchBatchDeadline runs Match on inputs in order until deadline passes and
+// returns the results parallel to inputs, along with how many were scanned.
+// Inputs left when the deadline hits get NotScanned. The deadline is checked
+// before each input, so a single long scan may overrun it.
+func (m *VsMatcher) MatchBatchDeadline(inputs []string, deadline time.Time) ([]int, int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	results := make([]int, len(inputs))
+	done := 0
+	for ; done < len(inputs) && time.Now().Before(deadline); done++ {
+		results[done] = m.match(inputs[done])
+	}
+	for i := done; i < len(inputs); i++ {
+		results[i] = NotScanned
+	}
+	return results, done
+}
+
+// ScanChannel reads inputs from in on workers goroutines until in is closed,
+// sends one MatchResult per input to out, and closes out once every worker
+// is done. A result holds the input, the first matching pattern and the end
+// offset of that match, with Start -1 as there is no start-of-match tracking;
+// inputs with no match get ID, Start and End of -1. Results arrive in no
+// particular order.
+//
+// Each worker scans with its own clone of the scratch space, so the workers
+// never take m's lock and run in parallel. ScanChannel blocks until out is
+// closed; run it in its own goroutine. It fails only if scratch cannot be
+// cloned, in which case out is closed and nothing is read from in. m must
+// not be closed while a scan is running.
+func (m *VsMatcher) ScanChannel(in <-chan string, out chan<- MatchResult, workers int) error {
+	defer close(out)
+	if workers < 1 {
+		workers = 1
+	}
+
+	m.mu.Lock()
+	scratches := make([]*hs.Scratch, 0, workers)
+	var err error
+	for range workers {
+		var s *hs.Scratch
+		if s, err = m.scratch.Clone(); err != nil {
+			break
+		}
+		scratches = append(scratches, s)
+	}
+	m.mu.Unlock()
+	defer func() {
+		for _, s := range scratches {
+			s.Free()
+		}
+	}()
+	if err != nil {
+		return fmt.Errorf("failed to clone scratch: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	for _, scratch := range scratches {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for input := range in {
+				out <- m.scanWith(scratch, input)
+			}
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+// scanWith finds the first match in input using the caller's scratch.
+func (m *VsMatcher) scanWith(scratch *hs.Scratch, input string) MatchResult {
+	r := MatchResult{ID: -1, Start: -1, End: -1, Input: input}
+	handler := hs.MatchHandler(func(id uint, from, to uint64, flags uint, context interface{}) error {
+		r.ID, r.End = int(id), int(to)
+		return hs.ErrScanTerminated
+	})
+
+	err := m.db.Scan([]byte(input), scratch, handler, nil)
+	if err != nil && err != hs.ErrScanTerminated {
+		return MatchResult{ID: -1, Start: -1, End: -1, Input: input}
+	}
+	return r
+}
@@ -0,0 +1,117 @@
+package vectorscan
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestVsMatcher_ScanAll(t *testing.T) {
+	m, err := NewVsMatcher([]string{`virus`})
+	if err != nil {
+		t.Fatalf("NewVsMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	inputs := make([]string, 3*progressInterval)
+	for i := range inputs {
+		inputs[i] = "clean.txt"
+	}
+	inputs[0] = "virus.exe"
+
+	// Full scan reports progress and completes
+	var reports []int
+	results, err := m.ScanAll(context.Background(), inputs, func(done, total int) {
+		reports = append(reports, done)
+	})
+	if err != nil {
+		t.Fatalf("ScanAll failed: %v", err)
+	}
+	if len(results) != len(inputs) || results[0] != 0 || results[1] != -1 {
+		t.Errorf("ScanAll results: len=%d, [0]=%d, [1]=%d", len(results), results[0], results[1])
+	}
+	if len(reports) != 3 || reports[len(reports)-1] != len(inputs) {
+		t.Errorf("progress reports = %v, want 3 ending at %d", reports, len(inputs))
+	}
+
+	// Cancel at the first progress report
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results, err = m.ScanAll(ctx, inputs, func(done, total int) { cancel() })
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ScanAll error = %v, want context.Canceled", err)
+	}
+	if len(results) != progressInterval {
+		t.Errorf("partial results = %d, want %d", len(results), progressInterval)
+	}
+}
+
+func TestVsMatcher_MatchBatchDeadline(t *testing.T) {
+	m, err := NewVsMatcher([]string{`virus`})
+	if err != nil {
+		t.Fatalf("NewVsMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	inputs := []string{"virus.exe", "clean.txt"}
+
+	results, done := m.MatchBatchDeadline(inputs, time.Now().Add(-time.Second))
+	if done != 0 {
+		t.Errorf("completed %d inputs past the deadline, want 0", done)
+	}
+	for i, r := range results {
+		if r != NotScanned {
+			t.Errorf("results[%d] = %d, want NotScanned", i, r)
+		}
+	}
+
+	results, done = m.MatchBatchDeadline(inputs, time.Now().Add(time.Minute))
+	if done != 2 || results[0] != 0 || results[1] != -1 {
+		t.Errorf("MatchBatchDeadline = %v, %d; want [0 -1], 2", results, done)
+	}
+}
+
+func TestVsMatcher_ScanChannel(t *testing.T) {
+	m, err := NewVsMatcher([]string{`virus`, `trojan`})
+	if err != nil {
+		t.Fatalf("NewVsMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	want := map[string]int{
+		"virus.exe":  0,
+		"trojan.dll": 1,
+		"clean.txt":  -1,
+	}
+
+	in := make(chan string)
+	out := make(chan MatchResult)
+	errc := make(chan error, 1)
+	go func() { errc <- m.ScanChannel(in, out, 4) }()
+	go func() {
+		for i := 0; i < 100; i++ {
+			for input := range want {
+				in <- input
+			}
+		}
+		close(in)
+	}()
+
+	n := 0
+	for r := range out {
+		n++
+		if r.ID != want[r.Input] {
+			t.Errorf("ScanChannel(%q) ID = %d, want %d", r.Input, r.ID, want[r.Input])
+		}
+		if r.ID == 0 && (r.Start != -1 || r.End != len("virus")) {
+			t.Errorf("ScanChannel(%q) = [%d, %d), want [-1, %d)", r.Input, r.Start, r.End, len("virus"))
+		}
+	}
+	if err := <-errc; err != nil {
+		t.Fatalf("ScanChannel failed: %v", err)
+	}
+	if n != 100*len(want) {
+		t.Errorf("got %d results, want %d", n, 100*len(want))
+	}
+}
@@ -0,0 +1,42 @@
+package vectorscan
+
+import "fmt"
+
+// ScoredMatcher is a VsMatcher whose patterns each carry a weight, so a scan
+// yields a risk score rather than a list of pattern indices.
+type ScoredMatcher struct {
+	*VsMatcher
+	weights []float64 // weight for each pattern index
+}
+
+// NewScoredMatcher compiles patterns into one database and pairs each with
+// the weight at the same index. weights must be as long as patterns.
+func NewScoredMatcher(patterns []string, weights []float64) (*ScoredMatcher, error) {
+	if len(weights) != len(patterns) {
+		return nil, fmt.Errorf("got %d weights for %d patterns", len(weights), len(patterns))
+	}
+
+	m, err := NewVsMatcher(patterns)
+	if err != nil {
+		return nil, err
+	}
+	return &ScoredMatcher{VsMatcher: m, weights: weights}, nil
+}
+
+// Score returns the sum of the weights of every pattern that matches input.
+// Each pattern counts once, however many times it matches.
+func (m *ScoredMatcher) Score(input string) float64 {
+	var score float64
+	for _, id := range m.MatchAll(input) {
+		score += m.weights[id]
+	}
+	return score
+}
+
+// Weight returns the weight for a pattern index.
+func (m *ScoredMatcher) Weight(id int) float64 {
+	if id < 0 || id >= len(m.weights) {
+		return 0
+	}
+	return m.weights[id]
+}
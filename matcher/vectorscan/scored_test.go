@@ -0,0 +1,32 @@
+package vectorscan
+
+import "testing"
+
+func TestScoredMatcher(t *testing.T) {
+	m, err := NewScoredMatcher(
+		[]string{`mimikatz`, `\.exe$`, `wannacry`},
+		[]float64{5, 0.5, 9},
+	)
+	if err != nil {
+		t.Fatalf("NewScoredMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	tests := []struct {
+		input string
+		want  float64
+	}{
+		{"/tmp/mimikatz.exe", 5.5},
+		{"/tmp/setup.exe", 0.5},
+		{"/usr/bin/ls", 0},
+	}
+	for _, tt := range tests {
+		if got := m.Score(tt.input); got != tt.want {
+			t.Errorf("Score(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+
+	if _, err := NewScoredMatcher([]string{`a`, `b`}, []float64{1}); err == nil {
+		t.Error("NewScoredMatcher with mismatched weights succeeded, want error")
+	}
+}
@@ -81,7 +81,7 @@ build_variant() {
         -s WASM=1 \
         -s STANDALONE_WASM=1 \
         --no-entry \
-        -s EXPORTED_FUNCTIONS='["_wasm_alloc","_wasm_free","_matcher_init","_matcher_match","_matcher_pattern_count","_matcher_close","_matcher_get_error","_matcher_check_platform","_malloc","_free"]' \
+        -s EXPORTED_FUNCTIONS='["_wasm_alloc","_wasm_free","_matcher_init","_matcher_match","_matcher_pattern_count","_matcher_close","_matcher_get_error","_matcher_get_error_len","_matcher_check_platform","_malloc","_free"]' \
         -s ERROR_ON_UNDEFINED_SYMBOLS=0 \
         -s TOTAL_MEMORY=67108864 \
         -s ALLOW_MEMORY_GROWTH=1
@@ -4,8 +4,10 @@ package wasmvs
 import (
 	_ "embed"
 	"fmt"
+	"log"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/bytecodealliance/wasmtime-go/v39"
 )
@@ -28,12 +30,48 @@ type WasmMatcher struct {
 	matcherClose  *wasmtime.Func
 	patternCount  *wasmtime.Func
 	getError      *wasmtime.Func
+	getErrorLen   *wasmtime.Func // optional; absent in older builds
 	checkPlatform *wasmtime.Func
 
 	patterns []string
 	mu       sync.Mutex
 }
 
+// logger receives diagnostic output; see SetLogger.
+var logger atomic.Pointer[func(format string, args ...any)]
+
+func init() {
+	SetLogger(log.Printf)
+}
+
+// SetLogger routes the package's diagnostic output, such as warnings about
+// unexpected module imports, to fn. The default is log.Printf; pass nil to
+// discard the output.
+func SetLogger(fn func(format string, args ...any)) {
+	if fn == nil {
+		fn = func(string, ...any) {}
+	}
+	logger.Store(&fn)
+}
+
+// logf writes a diagnostic message through the current logger.
+func logf(format string, args ...any) {
+	(*logger.Load())(format, args...)
+}
+
+// warnUnknownImports logs each env import that NewWasmMatcher does not
+// define on the linker. WASI imports are all provided by the linker.
+func warnUnknownImports(imports []*wasmtime.ImportType) {
+	for _, imp := range imports {
+		if imp.Module() != "env" {
+			continue
+		}
+		if name := *imp.Name(); name != "emscripten_notify_memory_growth" {
+			logf("Warning: unknown env import: %s", name)
+		}
+	}
+}
+
 // NewWasmMatcher creates a new WASM-based Vectorscan matcher.
 func NewWasmMatcher(patterns []string) (*WasmMatcher, error) {
 	if len(patterns) == 0 {
@@ -58,33 +96,7 @@ func NewWasmMatcher(patterns []string) (*WasmMatcher, error) {
 	wasiConfig := wasmtime.NewWasiConfig()
 	store.SetWasi(wasiConfig)
 
-	// Get module imports
-	imports := module.Imports()
-
-	// Build import list
-	var importExterns []wasmtime.AsExtern
-	for _, imp := range imports {
-		modName := imp.Module()
-		name := *imp.Name()
-
-		switch modName {
-		case "wasi_snapshot_preview1":
-			// WASI imports are handled by the linker
-			continue
-		case "env":
-			// Handle Emscripten env imports
-			switch name {
-			case "emscripten_notify_memory_growth":
-				fn := wasmtime.WrapFunc(store, func() {
-					// No-op callback for memory growth
-				})
-				importExterns = append(importExterns, fn)
-			default:
-				// Create stub for unknown env functions
-				fmt.Printf("Warning: unknown env import: %s\n", name)
-			}
-		}
-	}
+	warnUnknownImports(module.Imports())
 
 	// Use linker for WASI support
 	linker := wasmtime.NewLinker(engine)
@@ -132,6 +144,7 @@ func NewWasmMatcher(patterns []string) (*WasmMatcher, error) {
 	m.matcherClose = instance.GetFunc(store, "matcher_close")
 	m.patternCount = instance.GetFunc(store, "matcher_pattern_count")
 	m.getError = instance.GetFunc(store, "matcher_get_error")
+	m.getErrorLen = instance.GetFunc(store, "matcher_get_error_len")
 	m.checkPlatform = instance.GetFunc(store, "matcher_check_platform")
 
 	if m.wasmAlloc == nil || m.wasmFree == nil || m.matcherInit == nil ||
@@ -239,6 +252,15 @@ func (m *WasmMatcher) PatternCount() int {
 	return len(m.patterns)
 }
 
+// MemorySize returns the current size of the module's linear memory in bytes.
+// Linear memory never shrinks, so steady growth across repeated Match calls
+// points to allocations that are not being freed.
+func (m *WasmMatcher) MemorySize() uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return uint64(m.memory.DataSize(m.store))
+}
+
 // Close releases WASM resources.
 func (m *WasmMatcher) Close() {
 	if m.matcherClose != nil {
@@ -246,6 +268,10 @@ func (m *WasmMatcher) Close() {
 	}
 }
 
+// maxErrorScan bounds the null-terminator scan GetError falls back to for
+// modules without matcher_get_error_len.
+const maxErrorScan = 512
+
 // GetError returns the last error message from the WASM module.
 func (m *WasmMatcher) GetError() string {
 	if m.getError == nil {
@@ -259,17 +285,29 @@ func (m *WasmMatcher) GetError() string {
 	if ptr == 0 {
 		return ""
 	}
-	// Read null-terminated string from WASM memory
 	memData := m.memory.UnsafeData(m.store)
-	var buf []byte
-	for i := int32(0); i < 512; i++ {
-		b := memData[ptr+i]
-		if b == 0 {
-			break
+	if int(ptr) >= len(memData) {
+		return ""
+	}
+	memData = memData[ptr:]
+
+	// Prefer the exact length when the module exports it
+	if m.getErrorLen != nil {
+		if result, err := m.getErrorLen.Call(m.store); err == nil {
+			n := int(result.(int32))
+			if n >= 0 && n <= len(memData) {
+				return string(memData[:n])
+			}
 		}
-		buf = append(buf, b)
 	}
-	return string(buf)
+
+	// Fall back to reading a null-terminated string, up to maxErrorScan bytes
+	limit := min(maxErrorScan, len(memData))
+	n := 0
+	for n < limit && memData[n] != 0 {
+		n++
+	}
+	return string(memData[:n])
 }
 
 // CheckPlatform returns 0 if the platform is valid, non-zero otherwise.
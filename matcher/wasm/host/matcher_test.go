@@ -2,8 +2,11 @@ package wasmvs
 
 import (
 	"fmt"
+	"log"
+	"strings"
 	"testing"
 
+	"github.com/bytecodealliance/wasmtime-go/v39"
 	"github.com/paulstuart/cgo-ffi/matcher/testdata"
 )
 
@@ -127,6 +130,49 @@ func benchmarkWasmMatch(b *testing.B, patternCount int) {
 	}
 }
 
+// Benchmark with varying input sizes, matching the GoMatcher sweep. The
+// pattern_N patterns are plain literals, which is all the WASM backend takes.
+func BenchmarkWasmMatcher_Match_ShortInput(b *testing.B)  { benchmarkWasmInputSize(b, 100, 50) }
+func BenchmarkWasmMatcher_Match_MediumInput(b *testing.B) { benchmarkWasmInputSize(b, 100, 500) }
+func BenchmarkWasmMatcher_Match_LongInput(b *testing.B)   { benchmarkWasmInputSize(b, 100, 5000) }
+
+func benchmarkWasmInputSize(b *testing.B, patternCount, inputLen int) {
+	patterns := make([]string, patternCount)
+	for i := range patterns {
+		patterns[i] = fmt.Sprintf("pattern_%d", i)
+	}
+
+	m, err := NewWasmMatcher(patterns)
+	if err != nil {
+		b.Fatalf("NewWasmMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	// Generate input of specified length with match near end
+	input := generateInput(inputLen, patternCount-1)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Match(input)
+	}
+}
+
+// generateInput creates a string of approximately len characters
+// that contains the specified pattern
+func generateInput(length, patternIdx int) string {
+	pattern := fmt.Sprintf("pattern_%d", patternIdx)
+	if length <= len(pattern) {
+		return pattern
+	}
+
+	// Pad with non-matching content, put pattern near end
+	padding := make([]byte, length-len(pattern)-1)
+	for i := range padding {
+		padding[i] = 'x'
+	}
+	return string(padding) + " " + pattern
+}
+
 func BenchmarkWasmMatcher_ScanAllFiles(b *testing.B) {
 	// Use SimpleMalwarePatterns which work with WASM backend
 	m, err := NewWasmMatcher(testdata.SimpleMalwarePatterns)
@@ -142,3 +188,75 @@ func BenchmarkWasmMatcher_ScanAllFiles(b *testing.B) {
 		}
 	}
 }
+
+func TestWasmMatcher_LongError(t *testing.T) {
+	m, err := NewWasmMatcher([]string{`hello`})
+	if err != nil {
+		t.Fatalf("NewWasmMatcher failed: %v", err)
+	}
+	hasLen := m.getErrorLen != nil
+	m.Close()
+	if !hasLen {
+		t.Skip("matcher.wasm predates matcher_get_error_len; rebuild it")
+	}
+
+	// The compile error quotes the pattern, pushing the message past 512 bytes
+	bad := strings.Repeat("a", 600) + "(unclosed_group_end"
+	_, err = NewWasmMatcher([]string{bad})
+	if err == nil {
+		t.Fatal("expected compile error for unbalanced pattern")
+	}
+	if !strings.Contains(err.Error(), "unclosed_group_end") {
+		t.Errorf("error message truncated: %d bytes", len(err.Error()))
+	}
+}
+
+func TestWasmMatcher_MemoryStable(t *testing.T) {
+	m, err := NewWasmMatcher(testdata.SimpleMalwarePatterns)
+	if err != nil {
+		t.Fatalf("NewWasmMatcher failed: %v", err)
+	}
+	defer m.Close()
+
+	input := strings.Repeat("/tmp/some/long/path/", 50) + "file.exe"
+
+	// Warm up so the allocator has reached its steady-state heap
+	for i := 0; i < 100; i++ {
+		m.Match(input)
+	}
+	before := m.MemorySize()
+
+	for i := 0; i < 10000; i++ {
+		m.Match(input)
+	}
+	after := m.MemorySize()
+
+	// 10000 leaked inputs would be ~10 MB; allow one 64 KiB page of slack
+	if after > before+65536 {
+		t.Errorf("linear memory grew from %d to %d bytes over 10000 matches", before, after)
+	}
+}
+
+func TestSetLogger(t *testing.T) {
+	wasm, err := wasmtime.Wat2Wasm(`(module
+		(import "env" "emscripten_notify_memory_growth" (func (param i32)))
+		(import "env" "mystery_import" (func)))`)
+	if err != nil {
+		t.Fatalf("Wat2Wasm failed: %v", err)
+	}
+	module, err := wasmtime.NewModule(wasmtime.NewEngine(), wasm)
+	if err != nil {
+		t.Fatalf("NewModule failed: %v", err)
+	}
+
+	var logged []string
+	SetLogger(func(format string, args ...any) {
+		logged = append(logged, fmt.Sprintf(format, args...))
+	})
+	defer SetLogger(log.Printf)
+
+	warnUnknownImports(module.Imports())
+	if len(logged) != 1 || !strings.Contains(logged[0], "mystery_import") {
+		t.Errorf("logged %q, want one warning about mystery_import", logged)
+	}
+}
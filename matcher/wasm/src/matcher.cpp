@@ -9,8 +9,10 @@
 
 #include "hs.h"
 
-// Debug output buffer for WASM
-static char g_error_msg[512] = {0};
+// Debug output buffer for WASM. Compile errors quote the failing pattern,
+// so leave room for long expressions; g_error_len is the current length.
+static char g_error_msg[4096] = {0};
+static int g_error_len = 0;
 
 // Global state
 static hs_database_t *g_database = nullptr;
@@ -27,15 +29,22 @@ static int match_handler(unsigned int id, unsigned long long from,
     return 1;  // Non-zero to stop scanning
 }
 
+// Record the stored message length, accounting for truncation
+static void set_error_len(int n) {
+    if (n < 0) n = 0;
+    if (n >= (int)sizeof(g_error_msg)) n = sizeof(g_error_msg) - 1;
+    g_error_len = n;
+}
+
 // Helper to set error message
 static void set_error(const char* msg) {
-    snprintf(g_error_msg, sizeof(g_error_msg), "%s", msg);
+    set_error_len(snprintf(g_error_msg, sizeof(g_error_msg), "%s", msg));
 }
 
 static void set_error_fmt(const char* fmt, ...) {
     va_list args;
     va_start(args, fmt);
-    vsnprintf(g_error_msg, sizeof(g_error_msg), fmt, args);
+    set_error_len(vsnprintf(g_error_msg, sizeof(g_error_msg), fmt, args));
     va_end(args);
 }
 
@@ -116,8 +125,9 @@ int matcher_init(const char* patterns_data, int patterns_len) {
 
     if (err != HS_SUCCESS) {
         if (compile_err) {
-            set_error_fmt("Compile error at pattern %d: %s",
-                          compile_err->expression,
+            int bad = compile_err->expression;
+            set_error_fmt("Compile error at pattern %d (%s): %s", bad,
+                          bad >= 0 && bad < actual_count ? expressions[bad] : "?",
                           compile_err->message ? compile_err->message : "unknown");
             hs_free_compile_error(compile_err);
         } else {
@@ -185,6 +195,12 @@ const char* matcher_get_error(void) {
     return g_error_msg;
 }
 
+// Get length of last error message, excluding the null terminator
+__attribute__((export_name("matcher_get_error_len")))
+int matcher_get_error_len(void) {
+    return g_error_len;
+}
+
 // Check if platform is valid for Hyperscan
 __attribute__((export_name("matcher_check_platform")))
 int matcher_check_platform(void) {
@@ -1,5 +1,10 @@
 package ffi
 
+import (
+	"math"
+	"sort"
+)
+
 // Pure Go implementations for comparison benchmarks
 
 // GoSum computes sum using pure Go.
@@ -102,3 +107,280 @@ func GoScale(data []float64, scalar float64) {
 		data[i] *= scalar
 	}
 }
+
+// GoScaleRows multiplies row r of a row-major rows x cols matrix by scalars[r].
+func GoScaleRows(matrix []float64, rows, cols int, scalars []float64) {
+	for r := 0; r < rows; r++ {
+		GoScale(matrix[r*cols:(r+1)*cols], scalars[r])
+	}
+}
+
+// GoNormalizeRows scales each row of a row-major rows x cols matrix to unit
+// L2 norm, leaving zero rows unchanged.
+func GoNormalizeRows(matrix []float64, rows, cols int) {
+	for r := 0; r < rows; r++ {
+		row := matrix[r*cols : (r+1)*cols]
+		if norm := math.Sqrt(GoDot(row, row)); norm > 0 {
+			GoScale(row, 1/norm)
+		}
+	}
+}
+
+// GoValidate counts NaN and infinite values.
+func GoValidate(data []float64) (nanCount, infCount int) {
+	for _, v := range data {
+		if math.IsNaN(v) {
+			nanCount++
+		} else if math.IsInf(v, 0) {
+			infCount++
+		}
+	}
+	return nanCount, infCount
+}
+
+// GoScaleSatInt32 multiplies all elements by scalar in-place,
+// clamping to the int32 range instead of wrapping.
+func GoScaleSatInt32(data []int32, scalar int32) {
+	for i, v := range data {
+		p := int64(v) * int64(scalar)
+		if p > math.MaxInt32 {
+			p = math.MaxInt32
+		} else if p < math.MinInt32 {
+			p = math.MinInt32
+		}
+		data[i] = int32(p)
+	}
+}
+
+// GoSoftmax computes a numerically stable softmax.
+func GoSoftmax(data []float64) []float64 {
+	if len(data) == 0 {
+		return nil
+	}
+	peak := data[0]
+	for _, v := range data[1:] {
+		if v > peak {
+			peak = v
+		}
+	}
+	result := make([]float64, len(data))
+	var sum float64
+	for i, v := range data {
+		result[i] = math.Exp(v - peak)
+		sum += result[i]
+	}
+	for i := range result {
+		result[i] /= sum
+	}
+	return result
+}
+
+// GoQuantizeInt8 returns round(data[i]/scale) clamped to the int8 range.
+func GoQuantizeInt8(data []float64, scale float64) []int8 {
+	result := make([]int8, len(data))
+	for i, v := range data {
+		q := math.Round(v / scale)
+		switch {
+		case math.IsNaN(q):
+			q = 0
+		case q > math.MaxInt8:
+			q = math.MaxInt8
+		case q < math.MinInt8:
+			q = math.MinInt8
+		}
+		result[i] = int8(q)
+	}
+	return result
+}
+
+// GoQuantile returns the q-th quantile of data using linear interpolation
+// between the nearest ranks, or NaN if data is empty or q is outside [0, 1].
+func GoQuantile(data []float64, q float64) float64 {
+	if len(data) == 0 || !(q >= 0 && q <= 1) {
+		return math.NaN()
+	}
+	sorted := append([]float64(nil), data...)
+	sort.Float64s(sorted)
+
+	pos := q * float64(len(sorted)-1)
+	lo := int(pos)
+	if lo+1 >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	frac := pos - float64(lo)
+	return sorted[lo] + frac*(sorted[lo+1]-sorted[lo])
+}
+
+// GoExp returns math.Exp of each element.
+func GoExp(data []float64) []float64 {
+	result := make([]float64, len(data))
+	for i, v := range data {
+		result[i] = math.Exp(v)
+	}
+	return result
+}
+
+// GoLog returns math.Log of each element.
+func GoLog(data []float64) []float64 {
+	result := make([]float64, len(data))
+	for i, v := range data {
+		result[i] = math.Log(v)
+	}
+	return result
+}
+
+// GoPow returns math.Pow(data[i], exponent) for each element.
+func GoPow(data []float64, exponent float64) []float64 {
+	result := make([]float64, len(data))
+	for i, v := range data {
+		result[i] = math.Pow(v, exponent)
+	}
+	return result
+}
+
+// GoInterleave returns [a0, b0, a1, b1, ...] up to the shorter input.
+func GoInterleave(a, b []float64) []float64 {
+	n := min(len(a), len(b))
+	result := make([]float64, 2*n)
+	for i := 0; i < n; i++ {
+		result[2*i] = a[i]
+		result[2*i+1] = b[i]
+	}
+	return result
+}
+
+// GoDeinterleave splits data into its even- and odd-indexed elements.
+func GoDeinterleave(data []float64) (evens, odds []float64) {
+	evens = make([]float64, (len(data)+1)/2)
+	odds = make([]float64, len(data)/2)
+	for i, v := range data {
+		if i%2 == 0 {
+			evens[i/2] = v
+		} else {
+			odds[i/2] = v
+		}
+	}
+	return evens, odds
+}
+
+// GoRunningMax returns the running maximum of data.
+func GoRunningMax(data []float64) []float64 {
+	result := make([]float64, len(data))
+	for i, v := range data {
+		if i == 0 || v > result[i-1] {
+			result[i] = v
+		} else {
+			result[i] = result[i-1]
+		}
+	}
+	return result
+}
+
+// GoGreaterThan returns a mask with true where data[i] > threshold.
+func GoGreaterThan(data []float64, threshold float64) []bool {
+	mask := make([]bool, len(data))
+	for i, v := range data {
+		mask[i] = v > threshold
+	}
+	return mask
+}
+
+// GoCountGreater counts the elements strictly greater than threshold.
+func GoCountGreater(data []float64, threshold float64) int {
+	count := 0
+	for _, v := range data {
+		if v > threshold {
+			count++
+		}
+	}
+	return count
+}
+
+// GoAddSat returns a[i]+b[i] clamped to [lo, hi], passing NaN through.
+func GoAddSat(a, b []float64, lo, hi float64) []float64 {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	result := make([]float64, n)
+	for i := range result {
+		s := a[i] + b[i]
+		if s < lo {
+			s = lo
+		} else if s > hi {
+			s = hi
+		}
+		result[i] = s
+	}
+	return result
+}
+
+// GoOuter returns the row-major outer product of a and b.
+func GoOuter(a, b []float64) []float64 {
+	result := make([]float64, 0, len(a)*len(b))
+	for _, x := range a {
+		for _, y := range b {
+			result = append(result, x*y)
+		}
+	}
+	return result
+}
+
+// GoGeometricMean returns exp(mean(log(data))), or NaN if data is empty or
+// has an element that is not positive.
+func GoGeometricMean(data []float64) float64 {
+	if len(data) == 0 {
+		return math.NaN()
+	}
+	var logs float64
+	for _, v := range data {
+		if !(v > 0) {
+			return math.NaN()
+		}
+		logs += math.Log(v)
+	}
+	return math.Exp(logs / float64(len(data)))
+}
+
+// GoDotReproducible is the reference for VectorOps.DotReproducible: the
+// products are summed by a pairwise tree, adding neighbours on each pass and
+// carrying an odd tail.
+func GoDotReproducible(a, b []float64) float64 {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	if n == 0 {
+		return 0
+	}
+	s := make([]float64, n)
+	for i := range s {
+		s[i] = float64(a[i] * b[i])
+	}
+	for width := n; width > 1; {
+		half := width / 2
+		for i := 0; i < half; i++ {
+			s[i] = s[2*i] + s[2*i+1]
+		}
+		if width%2 == 1 {
+			s[half] = s[width-1]
+		}
+		width = half + width%2
+	}
+	return s[0]
+}
+
+// GoMeanStdDev returns the mean and population standard deviation of data
+// using Welford's single-pass algorithm, or NaN for both if data is empty.
+func GoMeanStdDev(data []float64) (mean, std float64) {
+	if len(data) == 0 {
+		return math.NaN(), math.NaN()
+	}
+	var m2 float64
+	for i, x := range data {
+		delta := x - mean
+		mean += delta / float64(i+1)
+		m2 += delta * (x - mean)
+	}
+	return mean, math.Sqrt(m2 / float64(len(data)))
+}
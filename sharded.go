@@ -0,0 +1,38 @@
+package ffi
+
+import "sync"
+
+// SumSharded splits data into len(shards) contiguous chunks, sums each chunk
+// on its own VectorOps concurrently, and adds the partial sums in order.
+// Each shard has its own buffers and lock, so the goroutines never contend.
+// Every shard needs capacity for at least ceil(len(data)/len(shards))
+// elements; anything past a shard's capacity is ignored, as with Sum.
+func SumSharded(shards []*VectorOps, data []float64) float64 {
+	if len(shards) == 0 || len(data) == 0 {
+		return 0
+	}
+
+	chunk := (len(data) + len(shards) - 1) / len(shards)
+	partial := make([]float64, len(shards))
+
+	var wg sync.WaitGroup
+	for i, ops := range shards {
+		start := min(i*chunk, len(data))
+		end := min(start+chunk, len(data))
+		if start == end {
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			partial[i] = ops.Sum(data[start:end])
+		}()
+	}
+	wg.Wait()
+
+	var sum float64
+	for _, p := range partial {
+		sum += p
+	}
+	return sum
+}
@@ -0,0 +1,52 @@
+package ffi
+
+import (
+	"math"
+	"testing"
+)
+
+func newShards(n, capacity int) []*VectorOps {
+	shards := make([]*VectorOps, n)
+	for i := range shards {
+		shards[i] = NewVectorOps(capacity)
+	}
+	return shards
+}
+
+func closeShards(shards []*VectorOps) {
+	for _, ops := range shards {
+		ops.Close()
+	}
+}
+
+func TestSumSharded(t *testing.T) {
+	data := makeData(10001) // not divisible by the shard count
+
+	shards := newShards(4, 2501)
+	defer closeShards(shards)
+
+	if got, want := SumSharded(shards, data), GoSum(data); math.Abs(got-want) > 1e-6 {
+		t.Errorf("SumSharded mismatch: Go=%v, C=%v", want, got)
+	}
+
+	// More shards than elements leaves the extra shards idle
+	small := []float64{1, 2, 3}
+	if got := SumSharded(shards, small); got != 6 {
+		t.Errorf("SumSharded(small) = %v, want 6", got)
+	}
+}
+
+func BenchmarkSumSharded_1(b *testing.B) { benchmarkSumSharded(b, 1) }
+func BenchmarkSumSharded_4(b *testing.B) { benchmarkSumSharded(b, 4) }
+
+func benchmarkSumSharded(b *testing.B, n int) {
+	const size = 1 << 20
+	data := makeData(size)
+	shards := newShards(n, (size+n-1)/n)
+	defer closeShards(shards)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = SumSharded(shards, data)
+	}
+}
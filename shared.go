@@ -0,0 +1,69 @@
+package ffi
+
+import "sync"
+
+// Package-level convenience API backed by a shared VectorOps.
+//
+// The shared instance is created lazily on first use and grows to fit the
+// largest input seen so far: when a call needs more capacity than the current
+// instance has, the old buffers are unpinned and replaced by a new VectorOps
+// sized exactly to that input. The instance is never shrunk or closed, so its
+// memory stays allocated for the life of the process.
+//
+// All calls are serialized through a single package mutex. Callers that care
+// about throughput or memory footprint should manage their own VectorOps.
+
+var (
+	sharedMu  sync.Mutex
+	sharedOps *VectorOps
+)
+
+// shared returns the package-level VectorOps with room for at least n elements.
+// Callers must hold sharedMu.
+func shared(n int) *VectorOps {
+	if n < 1 {
+		n = 1
+	}
+	if sharedOps == nil || sharedOps.capacity < n {
+		if sharedOps != nil {
+			sharedOps.Close()
+		}
+		sharedOps = NewVectorOps(n)
+	}
+	return sharedOps
+}
+
+// Sum returns the sum of all elements using the shared VectorOps.
+func Sum(data []float64) float64 {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+	return shared(len(data)).Sum(data)
+}
+
+// SumSIMD returns the SIMD-optimized sum using the shared VectorOps.
+func SumSIMD(data []float64) float64 {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+	return shared(len(data)).SumSIMD(data)
+}
+
+// Dot computes the dot product of two vectors using the shared VectorOps.
+func Dot(a, b []float64) float64 {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+	return shared(len(a)).Dot(a, b)
+}
+
+// Mul performs element-wise multiplication using the shared VectorOps.
+func Mul(a, b []float64) []float64 {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+	return shared(len(a)).Mul(a, b)
+}
+
+// Scale multiplies all elements by a scalar in-place using the shared VectorOps.
+func Scale(data []float64, scalar float64) {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+	shared(len(data)).Scale(data, scalar)
+}
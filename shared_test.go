@@ -0,0 +1,28 @@
+package ffi
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSharedSumGrows(t *testing.T) {
+	for _, n := range []int{10, 100, 1000, 10000} {
+		data := makeData(n)
+
+		goResult := GoSum(data)
+		cResult := Sum(data)
+
+		if math.Abs(goResult-cResult) > 1e-6 {
+			t.Errorf("Sum(%d) mismatch: Go=%v, C=%v", n, goResult, cResult)
+		}
+		if sharedOps.capacity < n {
+			t.Errorf("shared capacity = %d after Sum(%d), want >= %d", sharedOps.capacity, n, n)
+		}
+	}
+
+	// A smaller input must not shrink the shared instance
+	_ = Sum(makeData(5))
+	if sharedOps.capacity < 10000 {
+		t.Errorf("shared capacity shrank to %d", sharedOps.capacity)
+	}
+}
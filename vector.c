@@ -1,6 +1,9 @@
 // vector.c - C implementations of vector operations
 #include "vector.h"
 
+#include <math.h>
+#include <stdlib.h>
+
 // Simple sum
 double vector_sum(const double* arr, size_t len) {
     double sum = 0.0;
@@ -36,6 +39,10 @@ void vector_scale(double* arr, double scalar, size_t len) {
 // SIMD-optimized sum using loop unrolling
 // Compilers with -O2/-O3 will auto-vectorize this
 double vector_sum_simd(const double* arr, size_t len) {
+#ifdef VECTOR_PORTABLE
+    // Portable builds keep to the scalar kernel
+    return vector_sum(arr, len);
+#else
     double sum0 = 0.0, sum1 = 0.0, sum2 = 0.0, sum3 = 0.0;
     size_t i = 0;
 
@@ -53,4 +60,324 @@ double vector_sum_simd(const double* arr, size_t len) {
     }
 
     return sum0 + sum1 + sum2 + sum3;
+#endif
+}
+
+// Count non-finite values without branches so the loop vectorizes.
+// x - x is 0 for finite x and NaN for NaN or +/-Inf; x != x only for NaN.
+void vector_validate(const double* arr, size_t len, size_t* nan_count, size_t* inf_count) {
+    size_t nonfinite = 0, nans = 0;
+    for (size_t i = 0; i < len; i++) {
+        double d = arr[i] - arr[i];
+        nonfinite += d != d;
+        nans += arr[i] != arr[i];
+    }
+    *nan_count = nans;
+    *inf_count = nonfinite - nans;
+}
+
+// Softmax with the max subtracted first so exp never overflows
+void vector_softmax(const double* arr, double* result, size_t len) {
+    double max = arr[0];
+    for (size_t i = 1; i < len; i++) {
+        if (arr[i] > max) max = arr[i];
+    }
+
+    double sum = 0.0;
+    for (size_t i = 0; i < len; i++) {
+        result[i] = exp(arr[i] - max);
+        sum += result[i];
+    }
+
+    for (size_t i = 0; i < len; i++) {
+        result[i] /= sum;
+    }
+}
+
+// Quantize float64 to int8 with round-half-away-from-zero and clamping
+void vector_quantize_i8(const double* arr, double scale, int8_t* out, size_t len) {
+    for (size_t i = 0; i < len; i++) {
+        double q = round(arr[i] / scale);
+        if (q != q) q = 0.0;
+        if (q > 127.0) q = 127.0;
+        if (q < -128.0) q = -128.0;
+        out[i] = (int8_t)q;
+    }
+}
+
+static int compare_double(const void* a, const void* b) {
+    double x = *(const double*)a;
+    double y = *(const double*)b;
+    return (x > y) - (x < y);
+}
+
+// Quantile by sorting in-place, then interpolating between neighboring ranks
+double vector_quantile(double* arr, size_t len, double q) {
+    qsort(arr, len, sizeof(double), compare_double);
+    double pos = q * (double)(len - 1);
+    size_t lo = (size_t)pos;
+    if (lo + 1 >= len) {
+        return arr[len - 1];
+    }
+    double frac = pos - (double)lo;
+    return arr[lo] + frac * (arr[lo + 1] - arr[lo]);
+}
+
+// Element-wise exp
+void vector_exp(const double* arr, double* result, size_t len) {
+    for (size_t i = 0; i < len; i++) {
+        result[i] = exp(arr[i]);
+    }
+}
+
+// Element-wise natural log
+void vector_log(const double* arr, double* result, size_t len) {
+    for (size_t i = 0; i < len; i++) {
+        result[i] = log(arr[i]);
+    }
+}
+
+// Element-wise power. Small integral exponents take a repeated-squaring fast
+// path; everything else goes through libm pow.
+void vector_pow(const double* arr, double* result, size_t len, double exponent) {
+    if (exponent == floor(exponent) && fabs(exponent) <= 64) {
+        unsigned int e = (unsigned int)fabs(exponent);
+        for (size_t i = 0; i < len; i++) {
+            double base = arr[i];
+            double acc = 1.0;
+            for (unsigned int k = e; k > 0; k >>= 1) {
+                if (k & 1) {
+                    acc *= base;
+                }
+                base *= base;
+            }
+            result[i] = exponent < 0 ? 1.0 / acc : acc;
+        }
+        return;
+    }
+    for (size_t i = 0; i < len; i++) {
+        result[i] = pow(arr[i], exponent);
+    }
+}
+
+// Runtime CPUID check against the compile-time target. -march=native bakes in
+// the build machine's extensions; this catches binaries moved to older CPUs.
+int vector_cpu_supports_simd(void) {
+#if defined(__x86_64__) || defined(__i386__)
+    __builtin_cpu_init();
+#if defined(__AVX512F__)
+    return __builtin_cpu_supports("avx512f");
+#elif defined(__AVX2__)
+    return __builtin_cpu_supports("avx2");
+#elif defined(__AVX__)
+    return __builtin_cpu_supports("avx");
+#else
+    return __builtin_cpu_supports("sse2");
+#endif
+#else
+    return 1;
+#endif
+}
+
+// Compile-time SIMD target, matching the checks in vector_cpu_supports_simd
+const char* vector_simd_target(void) {
+#if defined(__AVX512F__)
+    return "avx512f";
+#elif defined(__AVX2__)
+    return "avx2";
+#elif defined(__AVX__)
+    return "avx";
+#elif defined(__SSE2__)
+    return "sse2";
+#elif defined(__ARM_NEON)
+    return "neon";
+#else
+    return "scalar";
+#endif
+}
+
+// Interleave a and b pairwise into result (2 * len elements)
+void vector_interleave(const double* a, const double* b, double* result, size_t len) {
+    for (size_t i = 0; i < len; i++) {
+        result[2 * i] = a[i];
+        result[2 * i + 1] = b[i];
+    }
+}
+
+// Deinterleave arr (len elements) into evens and odds
+void vector_deinterleave(const double* arr, double* evens, double* odds, size_t len) {
+    for (size_t i = 0; i < len / 2; i++) {
+        evens[i] = arr[2 * i];
+        odds[i] = arr[2 * i + 1];
+    }
+    if (len % 2) {
+        evens[len / 2] = arr[len - 1];
+    }
+}
+
+// Running maximum, carrying the peak forward
+void vector_runmax(const double* arr, double* result, size_t len) {
+    double peak = arr[0];
+    for (size_t i = 0; i < len; i++) {
+        if (arr[i] > peak) peak = arr[i];
+        result[i] = peak;
+    }
+}
+
+// Greater-than mask as 1.0/0.0 so it can feed further arithmetic
+void vector_gt(const double* arr, double threshold, double* result, size_t len) {
+    for (size_t i = 0; i < len; i++) {
+        result[i] = arr[i] > threshold ? 1.0 : 0.0;
+    }
+}
+
+// Branch-free count of elements above threshold
+size_t vector_count_gt(const double* arr, double threshold, size_t len) {
+    size_t count = 0;
+    for (size_t i = 0; i < len; i++) {
+        count += arr[i] > threshold;
+    }
+    return count;
+}
+
+// Element-wise add clamped to [lo, hi] in a single pass
+void vector_add_sat(const double* a, const double* b, double* result, size_t len, double lo, double hi) {
+    for (size_t i = 0; i < len; i++) {
+        double s = a[i] + b[i];
+        if (s < lo) {
+            s = lo;
+        } else if (s > hi) {
+            s = hi;
+        }
+        result[i] = s;
+    }
+}
+
+// Per-row scale of a row-major matrix in a single pass
+void vector_scale_rows(double* matrix, const double* scalars, size_t rows, size_t cols) {
+    for (size_t r = 0; r < rows; r++) {
+        double s = scalars[r];
+        double* row = matrix + r * cols;
+        for (size_t c = 0; c < cols; c++) {
+            row[c] *= s;
+        }
+    }
+}
+
+// Row-wise L2 normalization, reusing the dot and scale kernels per row
+void vector_normalize_rows(double* matrix, size_t rows, size_t cols) {
+    for (size_t r = 0; r < rows; r++) {
+        double* row = matrix + r * cols;
+        double norm = sqrt(vector_dot(row, row, cols));
+        if (norm > 0.0) {
+            vector_scale(row, 1.0 / norm, cols);
+        }
+    }
+}
+
+// Row-major outer product
+void vector_outer(const double* a, size_t lena, const double* b, size_t lenb, double* result) {
+    for (size_t i = 0; i < lena; i++) {
+        double ai = a[i];
+        double* row = result + i * lenb;
+        for (size_t j = 0; j < lenb; j++) {
+            row[j] = ai * b[j];
+        }
+    }
+}
+
+// Dot2: each product is split exactly into h + r with fma, each running sum
+// into t + e with TwoSum, and the error terms are accumulated separately.
+// Deliberately scalar; reassociating these steps would lose the error terms.
+double vector_dot_extended(const double* a, const double* b, size_t len) {
+    double p = 0.0, s = 0.0;
+    for (size_t i = 0; i < len; i++) {
+        double h = a[i] * b[i];
+        double r = fma(a[i], b[i], -h);
+        double t = p + h;
+        double z = t - p;
+        double e = (p - (t - z)) + (h - z);
+        p = t;
+        s += e + r;
+    }
+    return p + s;
+}
+
+// Geometric mean in log space, so long products cannot overflow or underflow
+double vector_geomean(const double* arr, size_t len) {
+    double logs = 0.0;
+    for (size_t i = 0; i < len; i++) {
+        if (!(arr[i] > 0.0)) {
+            return NAN;
+        }
+        logs += log(arr[i]);
+    }
+    return exp(logs / (double)len);
+}
+
+// Reproducible dot product. The products are rounded into scratch first, so
+// no fused multiply-add can form, then summed by a fixed pairwise tree:
+// each pass adds neighbours s[2i] + s[2i+1] and carries an odd tail. Every
+// addition is fixed by the algorithm, so vectorization cannot reorder it.
+double vector_dot_reproducible(const double* a, const double* b, double* scratch, size_t len) {
+    if (len == 0) {
+        return 0.0;
+    }
+    for (size_t i = 0; i < len; i++) {
+        scratch[i] = a[i] * b[i];
+    }
+    size_t width = len;
+    while (width > 1) {
+        size_t half = width / 2;
+        for (size_t i = 0; i < half; i++) {
+            scratch[i] = scratch[2 * i] + scratch[2 * i + 1];
+        }
+        if (width % 2 == 1) {
+            scratch[half] = scratch[width - 1];
+        }
+        width = half + width % 2;
+    }
+    return scratch[0];
+}
+
+// One exponential moving average step per element
+void vector_ema(const double* x, const double* prev, double* out, double alpha, size_t len) {
+    const double keep = 1.0 - alpha;
+    for (size_t i = 0; i < len; i++) {
+        out[i] = alpha * x[i] + keep * prev[i];
+    }
+}
+
+// Welford's algorithm: update the mean and the sum of squared deviations
+// from it one element at a time, so large offsets never cancel
+void vector_mean_std(const double* arr, size_t len, double* mean, double* std) {
+    double m = 0.0, m2 = 0.0;
+    for (size_t i = 0; i < len; i++) {
+        double delta = arr[i] - m;
+        m += delta / (double)(i + 1);
+        m2 += delta * (arr[i] - m);
+    }
+    *mean = m;
+    *std = sqrt(m2 / (double)len);
+}
+
+// Cache warming hint for benchmarks. __builtin_prefetch compiles to the
+// target's prefetch instruction, or to nothing where there is none.
+void vector_prefetch(const double* arr, double* result, size_t len) {
+    const size_t stride = 64 / sizeof(double);
+    for (size_t i = 0; i < len; i += stride) {
+        __builtin_prefetch(&arr[i], 0, 3);
+        __builtin_prefetch(&result[i], 1, 3);
+    }
+}
+
+// Saturating int32 scale: widen to 64 bits so the product cannot overflow,
+// then clamp back into int32 range
+void vector_scale_i32_sat(int32_t* arr, int32_t scalar, size_t len) {
+    for (size_t i = 0; i < len; i++) {
+        int64_t p = (int64_t)arr[i] * (int64_t)scalar;
+        if (p > INT32_MAX) p = INT32_MAX;
+        if (p < INT32_MIN) p = INT32_MIN;
+        arr[i] = (int32_t)p;
+    }
 }
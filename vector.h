@@ -20,4 +20,83 @@ void vector_scale(double* arr, double scalar, size_t len);
 // SIMD-optimized sum (if available)
 double vector_sum_simd(const double* arr, size_t len);
 
+// Count NaN and +/-Inf values in one pass
+void vector_validate(const double* arr, size_t len, size_t* nan_count, size_t* inf_count);
+
+// Numerically stable softmax: result[i] = exp(arr[i] - max) / sum
+void vector_softmax(const double* arr, double* result, size_t len);
+
+// Quantize to int8: out[i] = clamp(round(arr[i] / scale), -128, 127), NaN -> 0
+void vector_quantize_i8(const double* arr, double scale, int8_t* out, size_t len);
+
+// Sort arr in-place and return the q-th quantile (0 <= q <= 1), interpolating
+// linearly between the two nearest ranks
+double vector_quantile(double* arr, size_t len, double q);
+
+// Element-wise exponential: result[i] = exp(arr[i])
+void vector_exp(const double* arr, double* result, size_t len);
+
+// Element-wise natural log: result[i] = log(arr[i]), NaN/-Inf for arr[i] <= 0
+void vector_log(const double* arr, double* result, size_t len);
+
+// Element-wise power: result[i] = pow(arr[i], exponent)
+void vector_pow(const double* arr, double* result, size_t len, double exponent);
+
+// Returns 1 if the running CPU supports the widest x86 SIMD extension this
+// file was compiled for, 0 otherwise. Always 1 on other architectures.
+int vector_cpu_supports_simd(void);
+
+// Name of the widest SIMD extension this file was compiled for: "avx512f",
+// "avx2", "avx", "sse2", "neon" or "scalar".
+const char* vector_simd_target(void);
+
+// Interleave two arrays: result[2i] = a[i], result[2i+1] = b[i]
+void vector_interleave(const double* a, const double* b, double* result, size_t len);
+
+// Split even- and odd-indexed elements: evens[i] = arr[2i], odds[i] = arr[2i+1]
+void vector_deinterleave(const double* arr, double* evens, double* odds, size_t len);
+
+// Running maximum (peak hold): result[i] = max(arr[0..i])
+void vector_runmax(const double* arr, double* result, size_t len);
+
+// Comparison mask: result[i] = arr[i] > threshold ? 1.0 : 0.0
+void vector_gt(const double* arr, double threshold, double* result, size_t len);
+
+// Count elements strictly greater than threshold
+size_t vector_count_gt(const double* arr, double threshold, size_t len);
+
+// Saturating add: result[i] = clamp(a[i] + b[i], lo, hi), NaN passes through
+void vector_add_sat(const double* a, const double* b, double* result, size_t len, double lo, double hi);
+
+// Scale each row of a row-major rows x cols matrix in-place by scalars[row]
+void vector_scale_rows(double* matrix, const double* scalars, size_t rows, size_t cols);
+
+// L2-normalize each row of a row-major rows x cols matrix in-place; zero rows are left as is
+void vector_normalize_rows(double* matrix, size_t rows, size_t cols);
+
+// Outer product: result[i*lenb + j] = a[i] * b[j]
+void vector_outer(const double* a, size_t lena, const double* b, size_t lenb, double* result);
+
+// Compensated dot product (Ogita-Rump-Oishi Dot2), about twice double precision
+double vector_dot_extended(const double* a, const double* b, size_t len);
+
+// Geometric mean exp(mean(log(arr[i]))), NaN if any element is <= 0 or NaN
+double vector_geomean(const double* arr, size_t len);
+
+// Dot product summed by a fixed pairwise tree over scratch; bit-identical
+// across SIMD widths and platforms
+double vector_dot_reproducible(const double* a, const double* b, double* scratch, size_t len);
+
+// Exponential moving average step: out[i] = alpha*x[i] + (1-alpha)*prev[i]
+void vector_ema(const double* x, const double* prev, double* out, double alpha, size_t len);
+
+// Single-pass Welford mean and population standard deviation
+void vector_mean_std(const double* arr, size_t len, double* mean, double* std);
+
+// Prefetch every cache line of arr (for reading) and result (for writing)
+void vector_prefetch(const double* arr, double* result, size_t len);
+
+// Scale int32 array in-place, clamping to [INT32_MIN, INT32_MAX] on overflow
+void vector_scale_i32_sat(int32_t* arr, int32_t scalar, size_t len);
+
 #endif
@@ -57,6 +57,15 @@ WASM_EXPORT void scale(double scalar, uint32_t len) {
     }
 }
 
+// Exported as "abs"; the C name avoids clashing with the libc builtin abs().
+// export_name also makes the linker export it without an --export flag.
+__attribute__((export_name("abs"))) void abs_values(uint32_t len) {
+    size_t n = len < CAPACITY ? len : CAPACITY;
+    for (size_t i = 0; i < n; i++) {
+        buffer_a[i] = __builtin_fabs(buffer_a[i]);
+    }
+}
+
 WASM_EXPORT double sum_simd(uint32_t len) {
     size_t n = len < CAPACITY ? len : CAPACITY;
     // 4-way unrolling for better auto-vectorization
@@ -0,0 +1,44 @@
+package host
+
+import (
+	"math"
+	"os"
+)
+
+// compareTolerance is the relative difference CompareRuntimes accepts between
+// runtimes, which may sum in a different order.
+const compareTolerance = 1e-9
+
+// CompareRuntimes loads each module in paths that exists, runs Sum on data,
+// and reports the result from each runtime along with whether they all agree
+// within compareTolerance. Missing or unloadable modules are left out of the
+// results rather than treated as disagreement.
+func CompareRuntimes(paths map[WasmRuntime]string, data []float64) (map[WasmRuntime]float64, bool) {
+	results := make(map[WasmRuntime]float64)
+	for runtime, path := range paths {
+		wasmBytes, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		ops, err := NewWasmVectorOps(wasmBytes)
+		if err != nil {
+			continue
+		}
+		results[runtime] = ops.Sum(data)
+		ops.Close()
+	}
+
+	agree := true
+	var first float64
+	started := false
+	for _, result := range results {
+		if !started {
+			first, started = result, true
+			continue
+		}
+		if math.Abs(result-first) > compareTolerance*math.Max(1, math.Abs(first)) {
+			agree = false
+		}
+	}
+	return results, agree
+}
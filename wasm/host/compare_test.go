@@ -0,0 +1,31 @@
+package host
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCompareRuntimes(t *testing.T) {
+	data := makeData(1000)
+
+	results, agree := CompareRuntimes(wasmPaths, data)
+	if len(results) == 0 {
+		t.Skip("no WASM modules built (run build script first)")
+	}
+	if !agree {
+		t.Errorf("runtimes disagree: %v", results)
+	}
+
+	want := goSum(data)
+	for runtime, got := range results {
+		if math.Abs(got-want) > 1e-9 {
+			t.Errorf("%s Sum mismatch: Go=%v, WASM=%v", runtime, want, got)
+		}
+	}
+
+	// Absent modules are skipped, not reported
+	missing := map[WasmRuntime]string{RuntimeC: "does-not-exist.wasm"}
+	if results, agree := CompareRuntimes(missing, data); len(results) != 0 || !agree {
+		t.Errorf("CompareRuntimes(missing) = %v, %v; want empty, true", results, agree)
+	}
+}
@@ -0,0 +1,72 @@
+package host
+
+import (
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Shared fixture: one WasmVectorOps per runtime for the whole test binary.
+//
+// Loading a module compiles it, which dominates the run time of the small
+// correctness tests and benchmarks. Tests that only call stateless ops use
+// sharedOps instead of loadWasmOps; tests that change instance state
+// (Load, GrowMemory, Close) still load their own copy. TestMain closes the
+// shared instances after every test has run.
+
+var (
+	sharedMu    sync.Mutex
+	sharedOpsBy = map[WasmRuntime]*WasmVectorOps{}
+	sharedLoads = map[WasmRuntime]int{}
+)
+
+// sharedOps returns the shared WasmVectorOps for runtime, loading it on first
+// use. Callers must not Close the result.
+func sharedOps(t testing.TB, runtime WasmRuntime) *WasmVectorOps {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+
+	if ops, ok := sharedOpsBy[runtime]; ok {
+		return ops
+	}
+	ops := loadWasmOps(t, runtime)
+	sharedOpsBy[runtime] = ops
+	sharedLoads[runtime]++
+	return ops
+}
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+
+	sharedMu.Lock()
+	for runtime, ops := range sharedOpsBy {
+		ops.Close()
+		delete(sharedOpsBy, runtime)
+	}
+	sharedMu.Unlock()
+
+	os.Exit(code)
+}
+
+func testSharedOpsReused(t *testing.T, runtime WasmRuntime) {
+	start := time.Now()
+	first := sharedOps(t, runtime)
+	firstTime := time.Since(start)
+
+	start = time.Now()
+	second := sharedOps(t, runtime)
+	secondTime := time.Since(start)
+
+	if first != second {
+		t.Errorf("%s sharedOps returned a new instance on the second call", runtime)
+	}
+	if n := sharedLoads[runtime]; n != 1 {
+		t.Errorf("%s module loaded %d times, want 1", runtime, n)
+	}
+	t.Logf("%s sharedOps: first call %v, second call %v", runtime, firstTime, secondTime)
+}
+
+func TestSharedOpsReused_Rust(t *testing.T)   { testSharedOpsReused(t, RuntimeRust) }
+func TestSharedOpsReused_TinyGo(t *testing.T) { testSharedOpsReused(t, RuntimeTinyGo) }
+func TestSharedOpsReused_C(t *testing.T)      { testSharedOpsReused(t, RuntimeC) }
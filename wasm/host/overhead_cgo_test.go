@@ -0,0 +1,46 @@
+//go:build cgo
+
+package host
+
+import (
+	"testing"
+	"time"
+
+	ffi "github.com/paulstuart/cgo-ffi"
+)
+
+// BenchmarkOverhead_FFI_vs_WASM runs the same tiny Sum through cgo and each
+// WASM module head-to-head. Each WASM sub-benchmark also reports
+// ns-over-ffi/op: its per-call time minus that of a cgo baseline.
+func BenchmarkOverhead_FFI_vs_WASM(b *testing.B) {
+	data := makeData(10)
+	ops := ffi.NewVectorOps(len(data))
+	defer ops.Close()
+
+	// Measured up front so the WASM sub-benchmarks have a baseline even
+	// when -bench selects them without FFI.
+	const baselineCalls = 100000
+	start := time.Now()
+	for i := 0; i < baselineCalls; i++ {
+		_ = ops.Sum(data)
+	}
+	ffiPerCall := float64(time.Since(start).Nanoseconds()) / baselineCalls
+
+	b.Run("FFI", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = ops.Sum(data)
+		}
+	})
+
+	for _, runtime := range []WasmRuntime{RuntimeRust, RuntimeTinyGo, RuntimeC} {
+		b.Run("WASM_"+string(runtime), func(b *testing.B) {
+			w := sharedOps(b, runtime)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_ = w.Sum(data)
+			}
+			perCall := float64(b.Elapsed().Nanoseconds()) / float64(b.N)
+			b.ReportMetric(perCall-ffiPerCall, "ns-over-ffi/op")
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package host
+
+// Runtime is a WASM engine capable of instantiating a vector module.
+//
+// WasmVectorOps only reaches the module through Runtime, Instance and Func,
+// so the engine is chosen at construction time with
+// NewWasmVectorOpsWithRuntime. The default is wasmtime (NewWasmtimeRuntime),
+// which needs cgo; building with the wazero tag adds NewWazeroRuntime, a
+// pure-Go engine, which becomes the default when cgo is disabled. The
+// wasmtime-only APIs (CompileToBytes, NewWasmVectorOpsFromFile and
+// NewWasmVectorOpsFromCompiled) are not built without cgo.
+type Runtime interface {
+	// Instantiate compiles and instantiates a module, providing WASI
+	// imports if the module asks for them.
+	Instantiate(wasmBytes []byte) (Instance, error)
+}
+
+// Instance is an instantiated module with an exported linear memory.
+type Instance interface {
+	// Func returns the exported function with the given name, or nil.
+	Func(name string) Func
+
+	// Memory returns the module's linear memory. The slice aliases WASM
+	// memory directly and is invalidated if the memory grows.
+	Memory() []byte
+
+	// Grow adds pages of 64 KiB to the linear memory and returns the
+	// previous size in pages.
+	Grow(pages uint32) (uint32, error)
+
+	// Close releases the instance and its engine.
+	Close()
+}
+
+// Func is an exported WASM function. Arguments and results are passed as the
+// Go types matching their WASM value types: int32, int64, float32 or float64.
+// Call returns nil for functions with no results.
+type Func interface {
+	Call(args ...any) (any, error)
+}
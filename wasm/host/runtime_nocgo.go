@@ -0,0 +1,9 @@
+//go:build !cgo && wazero
+
+package host
+
+// defaultRuntime is wazero when cgo is disabled, so a CGO_ENABLED=0 build
+// with the wazero tag needs neither cgo nor libwasmtime.
+func defaultRuntime() Runtime {
+	return NewWazeroRuntime()
+}
@@ -0,0 +1,17 @@
+//go:build !cgo && !wazero
+
+package host
+
+import "errors"
+
+// defaultRuntime has no engine to offer: wasmtime needs cgo and wazero needs
+// the wazero tag. Loading a module fails with an error saying so.
+func defaultRuntime() Runtime {
+	return noRuntime{}
+}
+
+type noRuntime struct{}
+
+func (noRuntime) Instantiate([]byte) (Instance, error) {
+	return nil, errors.New("no WASM runtime: build with cgo enabled or with the wazero tag")
+}
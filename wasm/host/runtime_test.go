@@ -0,0 +1,78 @@
+package host
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// engines lists the runtimes the correctness suite runs against. Each
+// engine registers itself from a test file built only when it is available:
+// wasmtime with cgo, and the pure-Go engine with -tags wazero.
+var engines = map[string]func() Runtime{}
+
+// loadWasmOpsWith loads a WASM module on the given runtime if it exists
+func loadWasmOpsWith(t testing.TB, runtime WasmRuntime, rt Runtime) *WasmVectorOps {
+	absPath, err := filepath.Abs(getWasmPath(runtime))
+	if err != nil {
+		t.Skipf("cannot resolve path for %s: %v", runtime, err)
+	}
+	wasmBytes, err := os.ReadFile(absPath)
+	if os.IsNotExist(err) {
+		t.Skipf("WASM module not found: %s (run build script first)", absPath)
+	}
+	if err != nil {
+		t.Fatalf("failed to read %s WASM: %v", runtime, err)
+	}
+
+	ops, err := NewWasmVectorOpsWithRuntime(rt, wasmBytes)
+	if err != nil {
+		t.Fatalf("failed to load %s WASM: %v", runtime, err)
+	}
+	return ops
+}
+
+func testRuntimeCorrectness(t *testing.T, runtime WasmRuntime) {
+	for name, newRuntime := range engines {
+		t.Run(name, func(t *testing.T) {
+			ops := loadWasmOpsWith(t, runtime, newRuntime())
+			defer ops.Close()
+
+			a := makeData(1000)
+			b := makeData(1000)
+
+			if got, want := ops.Sum(a), goSum(a); math.Abs(got-want) > 1e-9 {
+				t.Errorf("%s Sum mismatch: Go=%v, WASM=%v", runtime, want, got)
+			}
+			if got, want := ops.SumSIMD(a), goSum(a); math.Abs(got-want) > 1e-9 {
+				t.Errorf("%s SumSIMD mismatch: Go=%v, WASM=%v", runtime, want, got)
+			}
+			if got, want := ops.Dot(a, b), goDot(a, b); math.Abs(got-want) > 1e-6 {
+				t.Errorf("%s Dot mismatch: Go=%v, WASM=%v", runtime, want, got)
+			}
+
+			product := ops.Mul(a, b)
+			if len(product) != len(a) {
+				t.Fatalf("%s Mul returned %d elements, want %d", runtime, len(product), len(a))
+			}
+			for i := range a {
+				if product[i] != a[i]*b[i] {
+					t.Fatalf("%s Mul[%d] = %v, want %v", runtime, i, product[i], a[i]*b[i])
+				}
+			}
+
+			scaled := append([]float64(nil), a...)
+			ops.Scale(scaled, 3)
+			for i := range a {
+				if scaled[i] != a[i]*3 {
+					t.Fatalf("%s Scale[%d] = %v, want %v", runtime, i, scaled[i], a[i]*3)
+				}
+			}
+		})
+	}
+}
+
+func TestRuntimeCorrectness_Rust(t *testing.T)   { testRuntimeCorrectness(t, RuntimeRust) }
+func TestRuntimeCorrectness_TinyGo(t *testing.T) { testRuntimeCorrectness(t, RuntimeTinyGo) }
+func TestRuntimeCorrectness_C(t *testing.T)      { testRuntimeCorrectness(t, RuntimeC) }
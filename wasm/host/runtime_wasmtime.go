@@ -0,0 +1,189 @@
+//go:build cgo
+
+package host
+
+import (
+	"fmt"
+
+	"github.com/bytecodealliance/wasmtime-go/v39"
+)
+
+// wasiConfig creates a minimal WASI configuration for modules that need it
+func wasiConfig() *wasmtime.WasiConfig {
+	config := wasmtime.NewWasiConfig()
+	return config
+}
+
+// defaultRuntime is wasmtime whenever cgo is available.
+func defaultRuntime() Runtime {
+	return NewWasmtimeRuntime()
+}
+
+// wasmtimeRuntime runs modules with wasmtime via cgo.
+type wasmtimeRuntime struct{}
+
+// NewWasmtimeRuntime returns the wasmtime-backed Runtime.
+// Each instantiated module gets its own engine and store.
+func NewWasmtimeRuntime() Runtime {
+	return wasmtimeRuntime{}
+}
+
+func (wasmtimeRuntime) Instantiate(wasmBytes []byte) (Instance, error) {
+	engine := wasmtime.NewEngine()
+	store := wasmtime.NewStore(engine)
+
+	module, err := wasmtime.NewModule(engine, wasmBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile module: %w", err)
+	}
+
+	return instantiateWasmtime(engine, store, module)
+}
+
+// wasmtimeInstance is an Instance backed by a wasmtime store.
+type wasmtimeInstance struct {
+	engine   *wasmtime.Engine
+	store    *wasmtime.Store
+	instance *wasmtime.Instance
+	memory   *wasmtime.Memory
+}
+
+func instantiateWasmtime(engine *wasmtime.Engine, store *wasmtime.Store, module *wasmtime.Module) (*wasmtimeInstance, error) {
+	// Check if module needs WASI imports
+	needsWasi := false
+	for _, imp := range module.Imports() {
+		if imp.Module() == "wasi_snapshot_preview1" {
+			needsWasi = true
+			break
+		}
+	}
+
+	var instance *wasmtime.Instance
+	var err error
+
+	if needsWasi {
+		// Use linker with WASI support
+		linker := wasmtime.NewLinker(engine)
+		if err := linker.DefineWasi(); err != nil {
+			return nil, fmt.Errorf("failed to define WASI: %w", err)
+		}
+
+		// Configure WASI
+		store.SetWasi(wasiConfig())
+
+		instance, err = linker.Instantiate(store, module)
+		if err != nil {
+			return nil, fmt.Errorf("failed to instantiate module with WASI: %w", err)
+		}
+	} else {
+		// Direct instantiation for non-WASI modules
+		instance, err = wasmtime.NewInstance(store, module, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to instantiate module: %w", err)
+		}
+	}
+
+	// Get memory export
+	memExtern := instance.GetExport(store, "memory")
+	if memExtern == nil {
+		return nil, fmt.Errorf("module does not export 'memory'")
+	}
+	memory := memExtern.Memory()
+	if memory == nil {
+		return nil, fmt.Errorf("'memory' export is not a memory")
+	}
+
+	return &wasmtimeInstance{
+		engine:   engine,
+		store:    store,
+		instance: instance,
+		memory:   memory,
+	}, nil
+}
+
+func (i *wasmtimeInstance) Func(name string) Func {
+	fn := i.instance.GetFunc(i.store, name)
+	if fn == nil {
+		return nil
+	}
+	return wasmtimeFunc{fn: fn, store: i.store}
+}
+
+func (i *wasmtimeInstance) Memory() []byte {
+	return i.memory.UnsafeData(i.store)
+}
+
+func (i *wasmtimeInstance) Grow(pages uint32) (uint32, error) {
+	prev, err := i.memory.Grow(i.store, uint64(pages))
+	return uint32(prev), err
+}
+
+func (i *wasmtimeInstance) Close() {
+	i.store.Close()
+	i.engine.Close()
+}
+
+// wasmtimeFunc binds a wasmtime function to the store it must be called in.
+type wasmtimeFunc struct {
+	fn    *wasmtime.Func
+	store *wasmtime.Store
+}
+
+func (f wasmtimeFunc) Call(args ...any) (any, error) {
+	return f.fn.Call(f.store, args...)
+}
+
+// NewWasmVectorOpsFromFile loads a WASM module from a file path.
+func NewWasmVectorOpsFromFile(path string) (*WasmVectorOps, error) {
+	engine := wasmtime.NewEngine()
+	store := wasmtime.NewStore(engine)
+
+	module, err := wasmtime.NewModuleFromFile(engine, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load module from %s: %w", path, err)
+	}
+
+	inst, err := instantiateWasmtime(engine, store, module)
+	if err != nil {
+		return nil, err
+	}
+	return newWasmVectorOps(inst)
+}
+
+// CompileToBytes compiles a WASM binary ahead of time and returns the
+// serialized native code. Cache the result and pass it to
+// NewWasmVectorOpsFromCompiled to skip compilation on later startups.
+// The artifact is only valid for the same wasmtime version and host CPU.
+func CompileToBytes(wasmBytes []byte) ([]byte, error) {
+	engine := wasmtime.NewEngine()
+	defer engine.Close()
+
+	module, err := wasmtime.NewModule(engine, wasmBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile module: %w", err)
+	}
+
+	compiled, err := module.Serialize()
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize module: %w", err)
+	}
+	return compiled, nil
+}
+
+// NewWasmVectorOpsFromCompiled loads a module previously produced by
+// CompileToBytes. No compilation happens; the native code is used as-is.
+func NewWasmVectorOpsFromCompiled(compiled []byte) (*WasmVectorOps, error) {
+	engine := wasmtime.NewEngine()
+	store := wasmtime.NewStore(engine)
+
+	module, err := wasmtime.NewModuleDeserialize(engine, compiled)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deserialize module: %w", err)
+	}
+
+	inst, err := instantiateWasmtime(engine, store, module)
+	if err != nil {
+		return nil, err
+	}
+	return newWasmVectorOps(inst)
+}
@@ -0,0 +1,40 @@
+//go:build cgo
+
+package host
+
+import (
+	"os"
+	"testing"
+)
+
+func init() {
+	engines["wasmtime"] = NewWasmtimeRuntime
+}
+
+func testCompiledRoundTrip(t *testing.T, runtime WasmRuntime) {
+	ops := loadWasmOps(t, runtime)
+	defer ops.Close()
+
+	wasmBytes, err := os.ReadFile(getWasmPath(runtime))
+	if err != nil {
+		t.Fatalf("failed to read %s WASM: %v", runtime, err)
+	}
+	compiled, err := CompileToBytes(wasmBytes)
+	if err != nil {
+		t.Fatalf("CompileToBytes failed: %v", err)
+	}
+	aot, err := NewWasmVectorOpsFromCompiled(compiled)
+	if err != nil {
+		t.Fatalf("NewWasmVectorOpsFromCompiled failed: %v", err)
+	}
+	defer aot.Close()
+
+	data := makeData(1000)
+	if got, want := aot.Sum(data), ops.Sum(data); got != want {
+		t.Errorf("%s Sum mismatch: compiled=%v, JIT=%v", runtime, got, want)
+	}
+}
+
+func TestCompiledRoundTrip_Rust(t *testing.T)   { testCompiledRoundTrip(t, RuntimeRust) }
+func TestCompiledRoundTrip_TinyGo(t *testing.T) { testCompiledRoundTrip(t, RuntimeTinyGo) }
+func TestCompiledRoundTrip_C(t *testing.T)      { testCompiledRoundTrip(t, RuntimeC) }
@@ -0,0 +1,131 @@
+//go:build wazero
+
+package host
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+)
+
+// wazeroRuntime runs modules with wazero, a pure-Go WASM engine.
+type wazeroRuntime struct{}
+
+// NewWazeroRuntime returns a wazero-backed Runtime. It needs no cgo, but is
+// only available when building with the wazero tag.
+func NewWazeroRuntime() Runtime {
+	return wazeroRuntime{}
+}
+
+func (wazeroRuntime) Instantiate(wasmBytes []byte) (Instance, error) {
+	ctx := context.Background()
+	rt := wazero.NewRuntime(ctx)
+
+	compiled, err := rt.CompileModule(ctx, wasmBytes)
+	if err != nil {
+		rt.Close(ctx)
+		return nil, fmt.Errorf("failed to compile module: %w", err)
+	}
+
+	// Check if module needs WASI imports
+	for _, fn := range compiled.ImportedFunctions() {
+		if module, _, _ := fn.Import(); module == wasi_snapshot_preview1.ModuleName {
+			wasi_snapshot_preview1.MustInstantiate(ctx, rt)
+			break
+		}
+	}
+
+	// Like the wasmtime host, don't run _start: the exports are called directly
+	mod, err := rt.InstantiateModule(ctx, compiled, wazero.NewModuleConfig().WithStartFunctions())
+	if err != nil {
+		rt.Close(ctx)
+		return nil, fmt.Errorf("failed to instantiate module: %w", err)
+	}
+
+	memory := mod.ExportedMemory("memory")
+	if memory == nil {
+		rt.Close(ctx)
+		return nil, fmt.Errorf("module does not export 'memory'")
+	}
+
+	return &wazeroInstance{ctx: ctx, rt: rt, mod: mod, memory: memory}, nil
+}
+
+// wazeroInstance is an Instance backed by a wazero runtime.
+type wazeroInstance struct {
+	ctx    context.Context
+	rt     wazero.Runtime
+	mod    api.Module
+	memory api.Memory
+}
+
+func (i *wazeroInstance) Func(name string) Func {
+	fn := i.mod.ExportedFunction(name)
+	if fn == nil {
+		return nil
+	}
+	return wazeroFunc{fn: fn, ctx: i.ctx}
+}
+
+func (i *wazeroInstance) Memory() []byte {
+	buf, _ := i.memory.Read(0, i.memory.Size())
+	return buf
+}
+
+func (i *wazeroInstance) Grow(pages uint32) (uint32, error) {
+	prev, ok := i.memory.Grow(pages)
+	if !ok {
+		return 0, fmt.Errorf("failed to grow memory by %d pages", pages)
+	}
+	return prev, nil
+}
+
+func (i *wazeroInstance) Close() {
+	i.rt.Close(i.ctx)
+}
+
+// wazeroFunc converts between Go values and wazero's uint64 encoding.
+type wazeroFunc struct {
+	fn  api.Function
+	ctx context.Context
+}
+
+func (f wazeroFunc) Call(args ...any) (any, error) {
+	params := make([]uint64, len(args))
+	for i, arg := range args {
+		switch v := arg.(type) {
+		case int32:
+			params[i] = api.EncodeI32(v)
+		case int64:
+			params[i] = api.EncodeI64(v)
+		case float32:
+			params[i] = api.EncodeF32(v)
+		case float64:
+			params[i] = api.EncodeF64(v)
+		default:
+			return nil, fmt.Errorf("unsupported argument type %T", arg)
+		}
+	}
+
+	results, err := f.fn.Call(f.ctx, params...)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	switch f.fn.Definition().ResultTypes()[0] {
+	case api.ValueTypeI32:
+		return api.DecodeI32(results[0]), nil
+	case api.ValueTypeF32:
+		return api.DecodeF32(results[0]), nil
+	case api.ValueTypeF64:
+		return api.DecodeF64(results[0]), nil
+	default:
+		return int64(results[0]), nil
+	}
+}
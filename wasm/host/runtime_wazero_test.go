@@ -0,0 +1,7 @@
+//go:build wazero
+
+package host
+
+func init() {
+	engines["wazero"] = NewWazeroRuntime
+}
@@ -9,32 +9,24 @@ package host
 
 import (
 	"fmt"
+	"math"
 	"sync"
+	"time"
 	"unsafe"
-
-	"github.com/bytecodealliance/wasmtime-go/v39"
 )
 
-// wasiConfig creates a minimal WASI configuration for modules that need it
-func wasiConfig() *wasmtime.WasiConfig {
-	config := wasmtime.NewWasiConfig()
-	return config
-}
-
 // WasmVectorOps provides WASM-backed vector operations.
 // After initialization, calls involve only memory copies and function invocations.
 type WasmVectorOps struct {
-	engine   *wasmtime.Engine
-	store    *wasmtime.Store
-	instance *wasmtime.Instance
-	memory   *wasmtime.Memory
+	inst Instance
 
 	// Cached function references
-	fnSum        *wasmtime.Func
-	fnDot        *wasmtime.Func
-	fnMul        *wasmtime.Func
-	fnScale      *wasmtime.Func
-	fnSumSimd    *wasmtime.Func
+	fnSum     Func
+	fnDot     Func
+	fnMul     Func
+	fnScale   Func
+	fnSumSimd Func
+	fnAbs     Func // optional; absent in older builds
 
 	// Pre-computed buffer offsets in WASM linear memory
 	bufferAOffset uint32
@@ -42,6 +34,9 @@ type WasmVectorOps struct {
 	resultOffset  uint32
 	capacity      uint32
 
+	// Number of elements currently held in buffer A by Load
+	loaded int
+
 	// Thread safety
 	mu sync.Mutex
 }
@@ -56,91 +51,63 @@ const (
 )
 
 // NewWasmVectorOps loads a WASM module and initializes the vector operations.
-// The wasmBytes should be the compiled WASM binary.
+// The wasmBytes should be the compiled WASM binary. The module runs on the
+// default runtime: wasmtime when cgo is enabled, otherwise wazero if built
+// with the wazero tag.
 func NewWasmVectorOps(wasmBytes []byte) (*WasmVectorOps, error) {
-	engine := wasmtime.NewEngine()
-	store := wasmtime.NewStore(engine)
-
-	module, err := wasmtime.NewModule(engine, wasmBytes)
-	if err != nil {
-		return nil, fmt.Errorf("failed to compile module: %w", err)
-	}
-
-	return newWasmVectorOpsFromModule(engine, store, module)
+	return NewWasmVectorOpsWithRuntime(defaultRuntime(), wasmBytes)
 }
 
-// NewWasmVectorOpsFromFile loads a WASM module from a file path.
-func NewWasmVectorOpsFromFile(path string) (*WasmVectorOps, error) {
-	engine := wasmtime.NewEngine()
-	store := wasmtime.NewStore(engine)
-
-	module, err := wasmtime.NewModuleFromFile(engine, path)
+// NewWasmVectorOpsWithRuntime loads a WASM module on the given runtime.
+func NewWasmVectorOpsWithRuntime(rt Runtime, wasmBytes []byte) (*WasmVectorOps, error) {
+	inst, err := rt.Instantiate(wasmBytes)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load module from %s: %w", path, err)
+		return nil, err
 	}
+	return newWasmVectorOps(inst)
+}
 
-	return newWasmVectorOpsFromModule(engine, store, module)
+// NewWasmVectorOpsWithRetry is NewWasmVectorOps retried up to attempts times,
+// for hosts where instantiation can fail transiently under memory pressure.
+// It sleeps backoff after the first failure and doubles the delay after each
+// later one. If every attempt fails, the last error is returned.
+func NewWasmVectorOpsWithRetry(wasmBytes []byte, attempts int, backoff time.Duration) (*WasmVectorOps, error) {
+	return withRetry(attempts, backoff, func() (*WasmVectorOps, error) {
+		return NewWasmVectorOps(wasmBytes)
+	})
 }
 
-func newWasmVectorOpsFromModule(engine *wasmtime.Engine, store *wasmtime.Store, module *wasmtime.Module) (*WasmVectorOps, error) {
-	// Check if module needs WASI imports
-	needsWasi := false
-	for _, imp := range module.Imports() {
-		if imp.Module() == "wasi_snapshot_preview1" {
-			needsWasi = true
-			break
-		}
+// withRetry calls load until it succeeds or attempts are used up.
+func withRetry(attempts int, backoff time.Duration, load func() (*WasmVectorOps, error)) (*WasmVectorOps, error) {
+	if attempts < 1 {
+		attempts = 1
 	}
-
-	var instance *wasmtime.Instance
 	var err error
-
-	if needsWasi {
-		// Use linker with WASI support
-		linker := wasmtime.NewLinker(engine)
-		if err := linker.DefineWasi(); err != nil {
-			return nil, fmt.Errorf("failed to define WASI: %w", err)
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
 		}
-
-		// Configure WASI
-		store.SetWasi(wasiConfig())
-
-		instance, err = linker.Instantiate(store, module)
-		if err != nil {
-			return nil, fmt.Errorf("failed to instantiate module with WASI: %w", err)
-		}
-	} else {
-		// Direct instantiation for non-WASI modules
-		instance, err = wasmtime.NewInstance(store, module, nil)
-		if err != nil {
-			return nil, fmt.Errorf("failed to instantiate module: %w", err)
+		var w *WasmVectorOps
+		if w, err = load(); err == nil {
+			return w, nil
 		}
 	}
+	return nil, fmt.Errorf("giving up after %d attempts: %w", attempts, err)
+}
 
-	// Get memory export
-	memExtern := instance.GetExport(store, "memory")
-	if memExtern == nil {
-		return nil, fmt.Errorf("module does not export 'memory'")
-	}
-	memory := memExtern.Memory()
-	if memory == nil {
-		return nil, fmt.Errorf("'memory' export is not a memory")
-	}
-
-	w := &WasmVectorOps{
-		engine:   engine,
-		store:    store,
-		instance: instance,
-		memory:   memory,
-	}
+func newWasmVectorOps(inst Instance) (*WasmVectorOps, error) {
+	w := &WasmVectorOps{inst: inst}
 
 	// Cache function references
 	if err := w.cacheFunctions(); err != nil {
+		inst.Close()
 		return nil, err
 	}
 
 	// Get buffer offsets from WASM module
 	if err := w.cacheOffsets(); err != nil {
+		inst.Close()
 		return nil, err
 	}
 
@@ -148,7 +115,7 @@ func newWasmVectorOpsFromModule(engine *wasmtime.Engine, store *wasmtime.Store,
 }
 
 func (w *WasmVectorOps) cacheFunctions() error {
-	funcs := map[string]**wasmtime.Func{
+	funcs := map[string]*Func{
 		"sum":      &w.fnSum,
 		"dot":      &w.fnDot,
 		"mul":      &w.fnMul,
@@ -157,67 +124,95 @@ func (w *WasmVectorOps) cacheFunctions() error {
 	}
 
 	for name, ptr := range funcs {
-		fn := w.instance.GetFunc(w.store, name)
+		fn := w.inst.Func(name)
 		if fn == nil {
 			return fmt.Errorf("module does not export function '%s'", name)
 		}
 		*ptr = fn
 	}
+	w.fnAbs = w.inst.Func("abs")
 	return nil
 }
 
 func (w *WasmVectorOps) cacheOffsets() error {
 	// Get buffer A offset
-	fn := w.instance.GetFunc(w.store, "get_buffer_a_offset")
+	fn := w.inst.Func("get_buffer_a_offset")
 	if fn == nil {
 		return fmt.Errorf("module does not export 'get_buffer_a_offset'")
 	}
-	result, err := fn.Call(w.store)
+	result, err := fn.Call()
 	if err != nil {
 		return fmt.Errorf("get_buffer_a_offset failed: %w", err)
 	}
 	w.bufferAOffset = uint32(result.(int32))
 
 	// Get buffer B offset
-	fn = w.instance.GetFunc(w.store, "get_buffer_b_offset")
+	fn = w.inst.Func("get_buffer_b_offset")
 	if fn == nil {
 		return fmt.Errorf("module does not export 'get_buffer_b_offset'")
 	}
-	result, err = fn.Call(w.store)
+	result, err = fn.Call()
 	if err != nil {
 		return fmt.Errorf("get_buffer_b_offset failed: %w", err)
 	}
 	w.bufferBOffset = uint32(result.(int32))
 
 	// Get result offset
-	fn = w.instance.GetFunc(w.store, "get_result_offset")
+	fn = w.inst.Func("get_result_offset")
 	if fn == nil {
 		return fmt.Errorf("module does not export 'get_result_offset'")
 	}
-	result, err = fn.Call(w.store)
+	result, err = fn.Call()
 	if err != nil {
 		return fmt.Errorf("get_result_offset failed: %w", err)
 	}
 	w.resultOffset = uint32(result.(int32))
 
 	// Get capacity
-	fn = w.instance.GetFunc(w.store, "get_capacity")
+	fn = w.inst.Func("get_capacity")
 	if fn == nil {
 		return fmt.Errorf("module does not export 'get_capacity'")
 	}
-	result, err = fn.Call(w.store)
+	result, err = fn.Call()
 	if err != nil {
 		return fmt.Errorf("get_capacity failed: %w", err)
 	}
 	w.capacity = uint32(result.(int32))
 
+	return w.checkOffsets(uint64(len(w.inst.Memory())))
+}
+
+// checkOffsets verifies that buffer A, buffer B and the result buffer, each
+// capacity float64s long, lie within memSize bytes of linear memory and do
+// not overlap. A module that breaks this would silently corrupt results.
+func (w *WasmVectorOps) checkOffsets(memSize uint64) error {
+	size := uint64(w.capacity) * 8
+	buffers := []struct {
+		name  string
+		start uint64
+	}{
+		{"buffer A", uint64(w.bufferAOffset)},
+		{"buffer B", uint64(w.bufferBOffset)},
+		{"result", uint64(w.resultOffset)},
+	}
+
+	for i, a := range buffers {
+		if a.start+size > memSize {
+			return fmt.Errorf("%s [%d, %d) extends past memory size %d", a.name, a.start, a.start+size, memSize)
+		}
+		for _, b := range buffers[i+1:] {
+			if a.start < b.start+size && b.start < a.start+size {
+				return fmt.Errorf("%s [%d, %d) overlaps %s [%d, %d)",
+					a.name, a.start, a.start+size, b.name, b.start, b.start+size)
+			}
+		}
+	}
 	return nil
 }
 
 // Close releases WASM resources.
 func (w *WasmVectorOps) Close() {
-	w.store.Close()
-	w.engine.Close()
+	w.inst.Close()
 }
 
 // Capacity returns the maximum number of elements the buffers can hold.
@@ -225,29 +220,82 @@ func (w *WasmVectorOps) Capacity() int {
 	return int(w.capacity)
 }
 
+// wasmPageSize is the size of a WASM linear memory page.
+const wasmPageSize = 65536
+
+// MemorySize returns the current size of the module's linear memory in bytes.
+func (w *WasmVectorOps) MemorySize() uint64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return uint64(len(w.inst.Memory()))
+}
+
+// GrowMemory grows linear memory by the given number of 64 KiB pages and
+// returns the previous size in pages. The vector buffers do not move, so
+// growing only matters for capacity planning and measuring grow cost.
+func (w *WasmVectorOps) GrowMemory(pages uint32) (uint32, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	prev, err := w.inst.Grow(pages)
+	if err != nil {
+		return 0, fmt.Errorf("failed to grow memory: %w", err)
+	}
+	return prev, nil
+}
+
 // copyToWasm copies float64 slice to WASM linear memory at the given offset.
 // Uses unsafe pointer casting for maximum performance (valid since f64 is same on both sides).
-func (w *WasmVectorOps) copyToWasm(data []float64, offset uint32) {
-	mem := w.memory.UnsafeData(w.store)
+// It returns an error instead of panicking if the copy would run past the end
+// of linear memory.
+func (w *WasmVectorOps) copyToWasm(data []float64, offset uint32) error {
+	if offset == w.bufferAOffset {
+		// Buffer A no longer holds what Load put there
+		w.loaded = 0
+	}
+	mem := w.inst.Memory()
+	if err := checkCopy(len(mem), offset, len(data)); err != nil {
+		return err
+	}
 	dst := mem[offset : offset+uint32(len(data)*8)]
 	src := unsafe.Slice((*byte)(unsafe.Pointer(&data[0])), len(data)*8)
 	copy(dst, src)
+	return nil
 }
 
 // copyFromWasm copies float64 values from WASM linear memory.
 // Uses unsafe pointer casting for maximum performance.
-func (w *WasmVectorOps) copyFromWasm(dst []float64, offset uint32) {
-	mem := w.memory.UnsafeData(w.store)
+func (w *WasmVectorOps) copyFromWasm(dst []float64, offset uint32) error {
+	mem := w.inst.Memory()
+	if err := checkCopy(len(mem), offset, len(dst)); err != nil {
+		return err
+	}
 	src := mem[offset : offset+uint32(len(dst)*8)]
 	dstBytes := unsafe.Slice((*byte)(unsafe.Pointer(&dst[0])), len(dst)*8)
 	copy(dstBytes, src)
+	return nil
+}
+
+// checkCopy reports an error if n float64s at offset do not fit in memSize
+// bytes of linear memory.
+func checkCopy(memSize int, offset uint32, n int) error {
+	if end := uint64(offset) + uint64(n)*8; end > uint64(memSize) {
+		return fmt.Errorf("copy of %d bytes at offset %d exceeds memory size %d", n*8, offset, memSize)
+	}
+	return nil
 }
 
 // Sum returns the sum of all elements.
 func (w *WasmVectorOps) Sum(data []float64) float64 {
+	result, _ := w.SumE(data)
+	return result
+}
+
+// SumE is Sum that reports a failed copy or call instead of returning 0.
+func (w *WasmVectorOps) SumE(data []float64) (float64, error) {
 	n := len(data)
 	if n == 0 {
-		return 0
+		return 0, nil
 	}
 	if n > int(w.capacity) {
 		n = int(w.capacity)
@@ -257,14 +305,22 @@ func (w *WasmVectorOps) Sum(data []float64) float64 {
 	defer w.mu.Unlock()
 
 	// Copy data to WASM buffer A
-	w.copyToWasm(data[:n], w.bufferAOffset)
+	if err := w.copyToWasm(data[:n], w.bufferAOffset); err != nil {
+		return 0, err
+	}
 
 	// Call WASM function
-	result, err := w.fnSum.Call(w.store, int32(n))
+	result, err := w.fnSum.Call(int32(n))
 	if err != nil {
-		return 0
+		return 0, err
 	}
-	return result.(float64)
+	return result.(float64), nil
+}
+
+// SumN is Sum that also reports how many elements were summed. processed is
+// less than len(data) when data exceeded the capacity and was truncated.
+func (w *WasmVectorOps) SumN(data []float64) (result float64, processed int) {
+	return w.Sum(data), min(len(data), int(w.capacity))
 }
 
 // SumSIMD uses the SIMD-optimized sum function.
@@ -280,9 +336,11 @@ func (w *WasmVectorOps) SumSIMD(data []float64) float64 {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
-	w.copyToWasm(data[:n], w.bufferAOffset)
+	if err := w.copyToWasm(data[:n], w.bufferAOffset); err != nil {
+		return 0
+	}
 
-	result, err := w.fnSumSimd.Call(w.store, int32(n))
+	result, err := w.fnSumSimd.Call(int32(n))
 	if err != nil {
 		return 0
 	}
@@ -291,9 +349,15 @@ func (w *WasmVectorOps) SumSIMD(data []float64) float64 {
 
 // Dot computes the dot product of two vectors.
 func (w *WasmVectorOps) Dot(a, b []float64) float64 {
+	result, _ := w.DotE(a, b)
+	return result
+}
+
+// DotE is Dot that reports a failed copy or call instead of returning 0.
+func (w *WasmVectorOps) DotE(a, b []float64) (float64, error) {
 	n := len(a)
 	if n == 0 || len(b) < n {
-		return 0
+		return 0, nil
 	}
 	if n > int(w.capacity) {
 		n = int(w.capacity)
@@ -302,21 +366,41 @@ func (w *WasmVectorOps) Dot(a, b []float64) float64 {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
-	w.copyToWasm(a[:n], w.bufferAOffset)
-	w.copyToWasm(b[:n], w.bufferBOffset)
+	if err := w.copyToWasm(a[:n], w.bufferAOffset); err != nil {
+		return 0, err
+	}
+	if err := w.copyToWasm(b[:n], w.bufferBOffset); err != nil {
+		return 0, err
+	}
 
-	result, err := w.fnDot.Call(w.store, int32(n))
+	result, err := w.fnDot.Call(int32(n))
 	if err != nil {
-		return 0
+		return 0, err
 	}
-	return result.(float64)
+	return result.(float64), nil
+}
+
+// DotN is Dot that also reports how many element pairs were used. processed
+// is less than len(a) when a exceeded the capacity, and 0 when b is shorter
+// than a.
+func (w *WasmVectorOps) DotN(a, b []float64) (result float64, processed int) {
+	if len(b) < len(a) {
+		return 0, 0
+	}
+	return w.Dot(a, b), min(len(a), int(w.capacity))
 }
 
 // Mul performs element-wise multiplication: result[i] = a[i] * b[i]
 func (w *WasmVectorOps) Mul(a, b []float64) []float64 {
+	result, _ := w.MulE(a, b)
+	return result
+}
+
+// MulE is Mul that reports a failed copy or call instead of returning nil.
+func (w *WasmVectorOps) MulE(a, b []float64) ([]float64, error) {
 	n := len(a)
 	if n == 0 || len(b) < n {
-		return nil
+		return nil, nil
 	}
 	if n > int(w.capacity) {
 		n = int(w.capacity)
@@ -325,17 +409,22 @@ func (w *WasmVectorOps) Mul(a, b []float64) []float64 {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
-	w.copyToWasm(a[:n], w.bufferAOffset)
-	w.copyToWasm(b[:n], w.bufferBOffset)
+	if err := w.copyToWasm(a[:n], w.bufferAOffset); err != nil {
+		return nil, err
+	}
+	if err := w.copyToWasm(b[:n], w.bufferBOffset); err != nil {
+		return nil, err
+	}
 
-	_, err := w.fnMul.Call(w.store, int32(n))
-	if err != nil {
-		return nil
+	if _, err := w.fnMul.Call(int32(n)); err != nil {
+		return nil, err
 	}
 
 	result := make([]float64, n)
-	w.copyFromWasm(result, w.resultOffset)
-	return result
+	if err := w.copyFromWasm(result, w.resultOffset); err != nil {
+		return nil, err
+	}
+	return result, nil
 }
 
 // MulInto performs element-wise multiplication into a provided destination.
@@ -351,10 +440,11 @@ func (w *WasmVectorOps) MulInto(a, b, dst []float64) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
-	w.copyToWasm(a[:n], w.bufferAOffset)
-	w.copyToWasm(b[:n], w.bufferBOffset)
+	if w.copyToWasm(a[:n], w.bufferAOffset) != nil || w.copyToWasm(b[:n], w.bufferBOffset) != nil {
+		return
+	}
 
-	_, err := w.fnMul.Call(w.store, int32(n))
+	_, err := w.fnMul.Call(int32(n))
 	if err != nil {
 		return
 	}
@@ -365,6 +455,64 @@ func (w *WasmVectorOps) MulInto(a, b, dst []float64) {
 // Scale multiplies all elements by a scalar.
 // Note: This modifies the internal buffer, not the input slice.
 func (w *WasmVectorOps) Scale(data []float64, scalar float64) {
+	w.ScaleE(data, scalar)
+}
+
+// ScaleE is Scale that reports a failed copy or call. data is left unchanged
+// on error.
+func (w *WasmVectorOps) ScaleE(data []float64, scalar float64) error {
+	n := len(data)
+	if n == 0 {
+		return nil
+	}
+	if n > int(w.capacity) {
+		n = int(w.capacity)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.copyToWasm(data[:n], w.bufferAOffset); err != nil {
+		return err
+	}
+
+	if _, err := w.fnScale.Call(scalar, int32(n)); err != nil {
+		return err
+	}
+
+	return w.copyFromWasm(data[:n], w.bufferAOffset)
+}
+
+// ScaleInto writes data multiplied by scalar into dst and leaves data
+// unchanged. dst must be at least as long as data, up to capacity. The
+// scaled values are copied straight out of buffer A, so there is no extra
+// copy compared with Scale.
+func (w *WasmVectorOps) ScaleInto(data []float64, scalar float64, dst []float64) {
+	n := len(data)
+	if n == 0 || len(dst) < min(n, int(w.capacity)) {
+		return
+	}
+	if n > int(w.capacity) {
+		n = int(w.capacity)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.copyToWasm(data[:n], w.bufferAOffset); err != nil {
+		return
+	}
+
+	if _, err := w.fnScale.Call(scalar, int32(n)); err != nil {
+		return
+	}
+
+	w.copyFromWasm(dst[:n], w.bufferAOffset)
+}
+
+// Abs replaces each element of data with its absolute value in-place.
+// Modules built before the abs export was added fall back to math.Abs in Go.
+func (w *WasmVectorOps) Abs(data []float64) {
 	n := len(data)
 	if n == 0 {
 		return
@@ -373,15 +521,183 @@ func (w *WasmVectorOps) Scale(data []float64, scalar float64) {
 		n = int(w.capacity)
 	}
 
+	if w.fnAbs == nil {
+		for i := range data[:n] {
+			data[i] = math.Abs(data[i])
+		}
+		return
+	}
+
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
-	w.copyToWasm(data[:n], w.bufferAOffset)
+	if err := w.copyToWasm(data[:n], w.bufferAOffset); err != nil {
+		return
+	}
 
-	_, err := w.fnScale.Call(w.store, scalar, int32(n))
+	_, err := w.fnAbs.Call(int32(n))
 	if err != nil {
 		return
 	}
 
 	w.copyFromWasm(data[:n], w.bufferAOffset)
 }
+
+// Load copies data into buffer A once so that several *Loaded operations can
+// run on it without repeating the host-to-WASM copy. Data beyond capacity is
+// ignored. Any other operation that uses buffer A discards the loaded data.
+func (w *WasmVectorOps) Load(data []float64) {
+	n := len(data)
+	if n > int(w.capacity) {
+		n = int(w.capacity)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if n > 0 && w.copyToWasm(data[:n], w.bufferAOffset) != nil {
+		n = 0
+	}
+	w.loaded = n
+}
+
+// MemoryBytes returns the module's linear memory for zero-copy bulk loads:
+// write little-endian float64s starting at BufferAOffset, then call
+// MarkLoaded and the *Loaded operations.
+//
+// The slice aliases WASM memory directly. It is invalidated whenever the
+// memory grows, through GrowMemory or from inside the module, and must not
+// be used after Close. Access through it is not synchronized with other
+// calls on w, and writes outside buffer A can corrupt the module.
+func (w *WasmVectorOps) MemoryBytes() []byte {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.inst.Memory()
+}
+
+// BufferAOffset returns the byte offset of buffer A in linear memory. It
+// holds Capacity float64s.
+func (w *WasmVectorOps) BufferAOffset() uint32 {
+	return w.bufferAOffset
+}
+
+// MarkLoaded declares that the first n elements of buffer A hold data
+// written through MemoryBytes, as if passed to Load, and returns the number
+// of elements marked after limiting n to capacity.
+func (w *WasmVectorOps) MarkLoaded(n int) int {
+	n = min(max(n, 0), int(w.capacity))
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.loaded = n
+	return n
+}
+
+// SumLoaded returns the sum of the data previously passed to Load.
+func (w *WasmVectorOps) SumLoaded() float64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.loaded == 0 {
+		return 0
+	}
+
+	result, err := w.fnSum.Call(int32(w.loaded))
+	if err != nil {
+		return 0
+	}
+	return result.(float64)
+}
+
+// SumSIMDLoaded returns the SIMD-optimized sum of the loaded data.
+func (w *WasmVectorOps) SumSIMDLoaded() float64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.loaded == 0 {
+		return 0
+	}
+
+	result, err := w.fnSumSimd.Call(int32(w.loaded))
+	if err != nil {
+		return 0
+	}
+	return result.(float64)
+}
+
+// ScaleLoaded multiplies the loaded data by a scalar inside WASM memory.
+// Later *Loaded calls see the scaled values; use ReadLoaded to copy them out.
+func (w *WasmVectorOps) ScaleLoaded(scalar float64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.loaded == 0 {
+		return
+	}
+
+	w.fnScale.Call(scalar, int32(w.loaded))
+}
+
+// ReadLoaded copies the loaded data back into dst and returns the number of
+// elements copied.
+func (w *WasmVectorOps) ReadLoaded(dst []float64) int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	n := w.loaded
+	if len(dst) < n {
+		n = len(dst)
+	}
+	if n == 0 {
+		return 0
+	}
+
+	if w.copyFromWasm(dst[:n], w.bufferAOffset) != nil {
+		return 0
+	}
+	return n
+}
+
+// selfTestLen is odd and not a multiple of the SIMD width, so SelfTest
+// exercises both vector and remainder loops.
+const selfTestLen = 17
+
+// SelfTest runs Sum, Dot and Mul on a small fixed vector and compares the
+// results with values computed in Go. Run it once after loading a module to
+// reject corrupt or incompatible builds before relying on them.
+func (w *WasmVectorOps) SelfTest() error {
+	n := min(selfTestLen, w.Capacity())
+	if n == 0 {
+		return fmt.Errorf("self-test: module reports zero capacity")
+	}
+
+	a := make([]float64, n)
+	b := make([]float64, n)
+	var wantSum, wantDot float64
+	for i := range a {
+		a[i] = float64(i + 1)
+		b[i] = 0.5*float64(i) - 3
+		wantSum += a[i]
+		wantDot += a[i] * b[i]
+	}
+
+	// All inputs and partial results are exact in float64, so any difference
+	// beyond rounding noise means a broken kernel
+	const tolerance = 1e-9
+	if got := w.Sum(a); math.Abs(got-wantSum) > tolerance {
+		return fmt.Errorf("self-test: Sum = %v, want %v", got, wantSum)
+	}
+	if got := w.Dot(a, b); math.Abs(got-wantDot) > tolerance {
+		return fmt.Errorf("self-test: Dot = %v, want %v", got, wantDot)
+	}
+	got := w.Mul(a, b)
+	if len(got) != n {
+		return fmt.Errorf("self-test: Mul returned %d elements, want %d", len(got), n)
+	}
+	for i := range got {
+		if want := a[i] * b[i]; math.Abs(got[i]-want) > tolerance {
+			return fmt.Errorf("self-test: Mul[%d] = %v, want %v", i, got[i], want)
+		}
+	}
+	return nil
+}
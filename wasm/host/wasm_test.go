@@ -1,11 +1,16 @@
 package host
 
 import (
+	"encoding/binary"
+	"errors"
+	"fmt"
 	"math"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 // WASM module paths (relative to test execution directory)
@@ -42,11 +47,15 @@ func loadWasmOps(t testing.TB, runtime WasmRuntime) *WasmVectorOps {
 		t.Skipf("cannot resolve path for %s: %v", runtime, err)
 	}
 
-	if _, err := os.Stat(absPath); os.IsNotExist(err) {
+	wasmBytes, err := os.ReadFile(absPath)
+	if os.IsNotExist(err) {
 		t.Skipf("WASM module not found: %s (run build script first)", absPath)
 	}
+	if err != nil {
+		t.Fatalf("failed to read %s WASM: %v", runtime, err)
+	}
 
-	ops, err := NewWasmVectorOpsFromFile(absPath)
+	ops, err := NewWasmVectorOps(wasmBytes)
 	if err != nil {
 		t.Fatalf("failed to load %s WASM: %v", runtime, err)
 	}
@@ -78,8 +87,7 @@ func goDot(a, b []float64) float64 {
 // --- Correctness Tests ---
 
 func testSumCorrectness(t *testing.T, runtime WasmRuntime) {
-	ops := loadWasmOps(t, runtime)
-	defer ops.Close()
+	ops := sharedOps(t, runtime)
 
 	data := makeData(1000)
 	goResult := goSum(data)
@@ -91,8 +99,7 @@ func testSumCorrectness(t *testing.T, runtime WasmRuntime) {
 }
 
 func testDotCorrectness(t *testing.T, runtime WasmRuntime) {
-	ops := loadWasmOps(t, runtime)
-	defer ops.Close()
+	ops := sharedOps(t, runtime)
 
 	a := makeData(1000)
 	b := makeData(1000)
@@ -112,12 +119,306 @@ func TestDotCorrectness_Rust(t *testing.T)   { testDotCorrectness(t, RuntimeRust
 func TestDotCorrectness_TinyGo(t *testing.T) { testDotCorrectness(t, RuntimeTinyGo) }
 func TestDotCorrectness_C(t *testing.T)      { testDotCorrectness(t, RuntimeC) }
 
+func testLoadedCorrectness(t *testing.T, runtime WasmRuntime) {
+	ops := loadWasmOps(t, runtime)
+	defer ops.Close()
+
+	data := makeData(1000)
+	want := ops.Sum(data)
+
+	ops.Load(data)
+	if got := ops.SumLoaded(); got != want {
+		t.Errorf("%s SumLoaded = %v, want %v", runtime, got, want)
+	}
+	if got := ops.SumSIMDLoaded(); math.Abs(got-want) > 1e-9 {
+		t.Errorf("%s SumSIMDLoaded = %v, want %v", runtime, got, want)
+	}
+
+	ops.ScaleLoaded(2)
+	if got := ops.SumLoaded(); math.Abs(got-2*want) > 1e-9 {
+		t.Errorf("%s SumLoaded after ScaleLoaded(2) = %v, want %v", runtime, got, 2*want)
+	}
+	scaled := make([]float64, len(data))
+	if n := ops.ReadLoaded(scaled); n != len(data) || scaled[0] != 2*data[0] {
+		t.Errorf("%s ReadLoaded = %d, scaled[0]=%v, want %d, %v", runtime, n, scaled[0], len(data), 2*data[0])
+	}
+
+	// Any other op reuses buffer A and discards the loaded data
+	ops.Sum(data[:10])
+	if got := ops.SumLoaded(); got != 0 {
+		t.Errorf("%s SumLoaded after Sum = %v, want 0", runtime, got)
+	}
+}
+
+func TestLoadedCorrectness_Rust(t *testing.T)   { testLoadedCorrectness(t, RuntimeRust) }
+func TestLoadedCorrectness_TinyGo(t *testing.T) { testLoadedCorrectness(t, RuntimeTinyGo) }
+func TestLoadedCorrectness_C(t *testing.T)      { testLoadedCorrectness(t, RuntimeC) }
+
+func testGrowMemory(t *testing.T, runtime WasmRuntime) {
+	ops := loadWasmOps(t, runtime)
+	defer ops.Close()
+
+	before := ops.MemorySize()
+	prev, err := ops.GrowMemory(1)
+	if err != nil {
+		t.Fatalf("%s GrowMemory failed: %v", runtime, err)
+	}
+	if uint64(prev)*wasmPageSize != before {
+		t.Errorf("%s GrowMemory returned %d pages, want %d", runtime, prev, before/wasmPageSize)
+	}
+	if after := ops.MemorySize(); after != before+wasmPageSize {
+		t.Errorf("%s MemorySize = %d after growing one page, want %d", runtime, after, before+wasmPageSize)
+	}
+
+	// Buffers are unaffected by growth
+	data := makeData(1000)
+	if got, want := ops.Sum(data), goSum(data); math.Abs(got-want) > 1e-9 {
+		t.Errorf("%s Sum after grow mismatch: Go=%v, WASM=%v", runtime, want, got)
+	}
+}
+
+func TestGrowMemory_Rust(t *testing.T)   { testGrowMemory(t, RuntimeRust) }
+func TestGrowMemory_TinyGo(t *testing.T) { testGrowMemory(t, RuntimeTinyGo) }
+func TestGrowMemory_C(t *testing.T)      { testGrowMemory(t, RuntimeC) }
+
+func testSumNTruncation(t *testing.T, runtime WasmRuntime) {
+	ops := sharedOps(t, runtime)
+
+	capacity := ops.Capacity()
+	data := makeData(capacity + 10)
+	sum, processed := ops.SumN(data)
+	if processed != capacity {
+		t.Errorf("%s SumN processed = %d, want %d", runtime, processed, capacity)
+	}
+	if want := goSum(data[:capacity]); math.Abs(sum-want) > 1e-6 {
+		t.Errorf("%s SumN = %v, want %v", runtime, sum, want)
+	}
+	if _, processed := ops.DotN(data, data); processed != capacity {
+		t.Errorf("%s DotN processed = %d, want %d", runtime, processed, capacity)
+	}
+}
+
+func TestSumNTruncation_Rust(t *testing.T)   { testSumNTruncation(t, RuntimeRust) }
+func TestSumNTruncation_TinyGo(t *testing.T) { testSumNTruncation(t, RuntimeTinyGo) }
+func TestSumNTruncation_C(t *testing.T)      { testSumNTruncation(t, RuntimeC) }
+
+func TestWithRetry(t *testing.T) {
+	errTransient := errors.New("transient failure")
+	flaky := func(failures int) (func() (*WasmVectorOps, error), *int) {
+		calls := 0
+		return func() (*WasmVectorOps, error) {
+			calls++
+			if calls <= failures {
+				return nil, fmt.Errorf("attempt %d: %w", calls, errTransient)
+			}
+			return &WasmVectorOps{}, nil
+		}, &calls
+	}
+
+	const backoff = 5 * time.Millisecond
+	load, calls := flaky(2)
+	start := time.Now()
+	ops, err := withRetry(3, backoff, load)
+	if err != nil || ops == nil {
+		t.Fatalf("withRetry = (%v, %v), want success on the third attempt", ops, err)
+	}
+	if *calls != 3 {
+		t.Errorf("loader called %d times, want 3", *calls)
+	}
+	// Backoff doubles: 5ms after the first failure, 10ms after the second
+	if elapsed := time.Since(start); elapsed < 3*backoff {
+		t.Errorf("withRetry returned after %v, want at least %v", elapsed, 3*backoff)
+	}
+
+	load, calls = flaky(5)
+	if _, err := withRetry(3, time.Millisecond, load); !errors.Is(err, errTransient) {
+		t.Errorf("withRetry error = %v, want it to wrap the last failure", err)
+	} else if !strings.Contains(err.Error(), "attempt 3") {
+		t.Errorf("withRetry error = %v, want the error from attempt 3", err)
+	}
+	if *calls != 3 {
+		t.Errorf("loader called %d times, want 3", *calls)
+	}
+}
+
+func TestCheckOffsets(t *testing.T) {
+	const capacity = 100 // 800 bytes per buffer
+	tests := []struct {
+		name    string
+		a, b, r uint32
+		memSize uint64
+		wantErr string
+	}{
+		{"disjoint", 0, 800, 1600, 2400, ""},
+		{"any order", 1600, 0, 800, 2400, ""},
+		{"A overlaps B", 0, 799, 1600, 2400, "buffer A [0, 800) overlaps buffer B"},
+		{"B overlaps result", 0, 1000, 1500, 4096, "buffer B [1000, 1800) overlaps result"},
+		{"same offset", 0, 800, 0, 2400, "buffer A [0, 800) overlaps result"},
+		{"past memory", 0, 800, 1600, 2399, "result [1600, 2400) extends past memory size 2399"},
+	}
+	for _, tt := range tests {
+		w := &WasmVectorOps{bufferAOffset: tt.a, bufferBOffset: tt.b, resultOffset: tt.r, capacity: capacity}
+		err := w.checkOffsets(tt.memSize)
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("%s: checkOffsets failed: %v", tt.name, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("%s: checkOffsets = %v, want error containing %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+// fakeInstance is an Instance with a fixed linear memory and no exports.
+type fakeInstance struct{ mem []byte }
+
+func (f *fakeInstance) Func(string) Func                  { return nil }
+func (f *fakeInstance) Memory() []byte                    { return f.mem }
+func (f *fakeInstance) Grow(pages uint32) (uint32, error) { return 0, errors.New("fixed memory") }
+func (f *fakeInstance) Close()                            {}
+
+func TestCopyOutOfBounds(t *testing.T) {
+	// Capacity claims room for 100 elements, but buffer A sits 32 bytes
+	// before the end of a 64-byte memory
+	w := &WasmVectorOps{
+		inst:          &fakeInstance{mem: make([]byte, 64)},
+		bufferAOffset: 32,
+		bufferBOffset: 0,
+		resultOffset:  0,
+		capacity:      100,
+	}
+	data := make([]float64, 10)
+
+	_, err := w.SumE(data)
+	if err == nil {
+		t.Fatal("SumE past the end of memory succeeded, want error")
+	}
+	for _, want := range []string{"offset 32", "80 bytes", "memory size 64"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("SumE error %q does not mention %q", err, want)
+		}
+	}
+
+	if _, err := w.MulE(data, data); err == nil {
+		t.Error("MulE past the end of memory succeeded, want error")
+	}
+	if err := w.ScaleE(data, 2); err == nil {
+		t.Error("ScaleE past the end of memory succeeded, want error")
+	}
+
+	// The non-E methods fall back to their zero results instead of panicking
+	if got := w.Sum(data); got != 0 {
+		t.Errorf("Sum past the end of memory = %v, want 0", got)
+	}
+}
+
+func testSelfTest(t *testing.T, runtime WasmRuntime) {
+	ops := sharedOps(t, runtime)
+	if err := ops.SelfTest(); err != nil {
+		t.Errorf("%s SelfTest failed: %v", runtime, err)
+	}
+}
+
+func TestSelfTest_Rust(t *testing.T)   { testSelfTest(t, RuntimeRust) }
+func TestSelfTest_TinyGo(t *testing.T) { testSelfTest(t, RuntimeTinyGo) }
+func TestSelfTest_C(t *testing.T)      { testSelfTest(t, RuntimeC) }
+
+func testMemoryBytes(t *testing.T, runtime WasmRuntime) {
+	ops := loadWasmOps(t, runtime)
+	defer ops.Close()
+
+	data := makeData(1000)
+	mem := ops.MemoryBytes()
+	off := ops.BufferAOffset()
+	for i, v := range data {
+		binary.LittleEndian.PutUint64(mem[off+uint32(i*8):], math.Float64bits(v))
+	}
+
+	if n := ops.MarkLoaded(len(data)); n != len(data) {
+		t.Fatalf("%s MarkLoaded(%d) = %d", runtime, len(data), n)
+	}
+	if got, want := ops.SumLoaded(), ops.Sum(data); got != want {
+		t.Errorf("%s SumLoaded after direct write = %v, want %v", runtime, got, want)
+	}
+	if n := ops.MarkLoaded(ops.Capacity() + 1); n != ops.Capacity() {
+		t.Errorf("%s MarkLoaded past capacity = %d, want %d", runtime, n, ops.Capacity())
+	}
+}
+
+func TestMemoryBytes_Rust(t *testing.T)   { testMemoryBytes(t, RuntimeRust) }
+func TestMemoryBytes_TinyGo(t *testing.T) { testMemoryBytes(t, RuntimeTinyGo) }
+func TestMemoryBytes_C(t *testing.T)      { testMemoryBytes(t, RuntimeC) }
+
+func testScaleInto(t *testing.T, runtime WasmRuntime) {
+	ops := sharedOps(t, runtime)
+
+	data := makeData(1000)
+	orig := append([]float64(nil), data...)
+	dst := make([]float64, len(data))
+
+	ops.ScaleInto(data, 2.5, dst)
+	for i := range data {
+		if data[i] != orig[i] {
+			t.Fatalf("%s ScaleInto modified data[%d]: %v, was %v", runtime, i, data[i], orig[i])
+		}
+		if want := orig[i] * 2.5; math.Abs(dst[i]-want) > 1e-9 {
+			t.Fatalf("%s ScaleInto dst[%d] = %v, want %v", runtime, i, dst[i], want)
+		}
+	}
+
+	// A short dst is rejected without writing
+	short := make([]float64, 10)
+	ops.ScaleInto(data, 2.5, short)
+	for i, v := range short {
+		if v != 0 {
+			t.Fatalf("%s ScaleInto wrote short dst[%d] = %v", runtime, i, v)
+		}
+	}
+}
+
+func TestScaleInto_Rust(t *testing.T)   { testScaleInto(t, RuntimeRust) }
+func TestScaleInto_TinyGo(t *testing.T) { testScaleInto(t, RuntimeTinyGo) }
+func TestScaleInto_C(t *testing.T)      { testScaleInto(t, RuntimeC) }
+
+func testAbsCorrectness(t *testing.T, runtime WasmRuntime) {
+	ops := sharedOps(t, runtime)
+	if ops.fnAbs == nil {
+		t.Skipf("%s module does not export abs (rebuild it)", runtime)
+	}
+
+	data := []float64{-3.5, 0, 2, -0.0, math.Inf(-1), -1e-300, 7}
+	want := make([]float64, len(data))
+	for i, v := range data {
+		want[i] = math.Abs(v)
+	}
+	ops.Abs(data)
+	for i := range data {
+		if data[i] != want[i] || math.Signbit(data[i]) {
+			t.Errorf("%s Abs[%d] = %v, want %v", runtime, i, data[i], want[i])
+		}
+	}
+
+	mixed := makeData(1000)
+	for i := range mixed {
+		mixed[i] -= 50
+	}
+	got := append([]float64(nil), mixed...)
+	ops.Abs(got)
+	for i := range mixed {
+		if got[i] != math.Abs(mixed[i]) {
+			t.Fatalf("%s Abs[%d] = %v, want %v", runtime, i, got[i], math.Abs(mixed[i]))
+		}
+	}
+}
+
+func TestAbsCorrectness_Rust(t *testing.T)   { testAbsCorrectness(t, RuntimeRust) }
+func TestAbsCorrectness_TinyGo(t *testing.T) { testAbsCorrectness(t, RuntimeTinyGo) }
+func TestAbsCorrectness_C(t *testing.T)      { testAbsCorrectness(t, RuntimeC) }
+
 // --- Benchmarks ---
 
 // Benchmark helpers
 func benchmarkWasmSum(b *testing.B, runtime WasmRuntime, n int) {
-	ops := loadWasmOps(b, runtime)
-	defer ops.Close()
+	ops := sharedOps(b, runtime)
 
 	data := makeData(n)
 	b.ResetTimer()
@@ -127,8 +428,7 @@ func benchmarkWasmSum(b *testing.B, runtime WasmRuntime, n int) {
 }
 
 func benchmarkWasmSumSIMD(b *testing.B, runtime WasmRuntime, n int) {
-	ops := loadWasmOps(b, runtime)
-	defer ops.Close()
+	ops := sharedOps(b, runtime)
 
 	data := makeData(n)
 	b.ResetTimer()
@@ -138,8 +438,7 @@ func benchmarkWasmSumSIMD(b *testing.B, runtime WasmRuntime, n int) {
 }
 
 func benchmarkWasmDot(b *testing.B, runtime WasmRuntime, n int) {
-	ops := loadWasmOps(b, runtime)
-	defer ops.Close()
+	ops := sharedOps(b, runtime)
 
 	a := makeData(n)
 	c := makeData(n)
@@ -150,8 +449,7 @@ func benchmarkWasmDot(b *testing.B, runtime WasmRuntime, n int) {
 }
 
 func benchmarkWasmMul(b *testing.B, runtime WasmRuntime, n int) {
-	ops := loadWasmOps(b, runtime)
-	defer ops.Close()
+	ops := sharedOps(b, runtime)
 
 	a := makeData(n)
 	c := makeData(n)
@@ -232,8 +530,7 @@ func BenchmarkDot_Go_Ref_100000(b *testing.B) { benchmarkGoDot(b, 100000) }
 
 // --- Overhead Benchmarks (small data to measure call overhead) ---
 func BenchmarkOverhead_Wasm_Rust(b *testing.B) {
-	ops := loadWasmOps(b, RuntimeRust)
-	defer ops.Close()
+	ops := sharedOps(b, RuntimeRust)
 	data := makeData(10)
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -242,8 +539,7 @@ func BenchmarkOverhead_Wasm_Rust(b *testing.B) {
 }
 
 func BenchmarkOverhead_Wasm_TinyGo(b *testing.B) {
-	ops := loadWasmOps(b, RuntimeTinyGo)
-	defer ops.Close()
+	ops := sharedOps(b, RuntimeTinyGo)
 	data := makeData(10)
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -252,8 +548,7 @@ func BenchmarkOverhead_Wasm_TinyGo(b *testing.B) {
 }
 
 func BenchmarkOverhead_Wasm_C(b *testing.B) {
-	ops := loadWasmOps(b, RuntimeC)
-	defer ops.Close()
+	ops := sharedOps(b, RuntimeC)
 	data := makeData(10)
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -268,3 +563,30 @@ func BenchmarkOverhead_Go_Ref(b *testing.B) {
 		_ = goSum(data)
 	}
 }
+
+// BenchmarkGrowMemory measures the latency of growing linear memory by one
+// page. The module is reloaded every growBatch pages to stay far below the
+// 4 GiB limit.
+func BenchmarkGrowMemory_Rust(b *testing.B)   { benchmarkGrowMemory(b, RuntimeRust) }
+func BenchmarkGrowMemory_TinyGo(b *testing.B) { benchmarkGrowMemory(b, RuntimeTinyGo) }
+func BenchmarkGrowMemory_C(b *testing.B)      { benchmarkGrowMemory(b, RuntimeC) }
+
+func benchmarkGrowMemory(b *testing.B, runtime WasmRuntime) {
+	const growBatch = 1024
+
+	ops := loadWasmOps(b, runtime)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if i > 0 && i%growBatch == 0 {
+			b.StopTimer()
+			ops.Close()
+			ops = loadWasmOps(b, runtime)
+			b.StartTimer()
+		}
+		if _, err := ops.GrowMemory(1); err != nil {
+			b.Fatalf("GrowMemory failed: %v", err)
+		}
+	}
+	b.StopTimer()
+	ops.Close()
+}
@@ -96,6 +96,16 @@ pub extern "C" fn scale(scalar: f64, len: u32) {
     }
 }
 
+#[no_mangle]
+pub extern "C" fn abs(len: u32) {
+    let len = (len as usize).min(CAPACITY);
+    unsafe {
+        for i in 0..len {
+            BUFFER_A.set(i, BUFFER_A.get(i).abs());
+        }
+    }
+}
+
 #[no_mangle]
 pub extern "C" fn sum_simd(len: u32) -> f64 {
     let len = (len as usize).min(CAPACITY);
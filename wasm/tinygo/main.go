@@ -7,7 +7,10 @@
 
 package main
 
-import "unsafe"
+import (
+	"math"
+	"unsafe"
+)
 
 // Pre-allocated buffer capacity (100K f64 elements = 800KB per buffer)
 const capacity = 100_000
@@ -68,6 +71,17 @@ func scale(scalar float64, len uint32) {
 	}
 }
 
+//export abs
+func abs(len uint32) {
+	n := int(len)
+	if n > capacity {
+		n = capacity
+	}
+	for i := 0; i < n; i++ {
+		bufferA[i] = math.Abs(bufferA[i])
+	}
+}
+
 //export sum_simd
 func sumSimd(len uint32) float64 {
 	n := int(len)